@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"ember/internal/config"
+	"ember/internal/player"
+	"ember/internal/ui"
+)
+
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println("Usage: ember doctor")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	configStatus := config.Path()
+	if cfg, err := config.Load(); err != nil {
+		configStatus += " (error: " + err.Error() + ")"
+	} else if cfg.Player.Path != "" {
+		if err := player.SetPath(cfg.Player.Path); err != nil {
+			configStatus += " (" + err.Error() + ")"
+		}
+	}
+	if _, err := os.Stat(config.Path()); err != nil {
+		configStatus += " (not present, using defaults)"
+	}
+
+	mpv := player.Version()
+	if mpv == "" {
+		mpv = "not found (install mpv and make sure it is in PATH)"
+	}
+	fmt.Println(terminalReport())
+	fmt.Println("mpv:       " + mpv)
+	fmt.Println("config:    " + configStatus)
+	return nil
+}
+
+func terminalReport() string {
+	caps := ui.DetectTerminal()
+	term := caps.Term
+	if term == "" {
+		term = "unset"
+	}
+
+	colors := caps.Colors.Name()
+	switch colors {
+	case "ANSI256":
+		colors += " (images and colors reduced to 256 colors)"
+	case "ANSI":
+		colors += " (images and colors reduced to 16 colors)"
+	case "Ascii":
+		colors += " (no color; is the output a terminal?)"
+	}
+
+	unicode := "yes"
+	if !caps.Unicode {
+		unicode = "no (ASCII borders and symbols; set EMBER_ASCII=0 to override)"
+	}
+
+	graphics := "none (text-based posters)"
+	if caps.Graphics != "" {
+		graphics = fmt.Sprintf("%s (try ember -renderer %s)", caps.Graphics, caps.Graphics)
+	}
+
+	lines := []string{
+		"terminal:  " + term,
+		"colors:    " + colors,
+		"unicode:   " + unicode,
+		"graphics:  " + graphics,
+		"renderers: " + strings.Join(ui.ImageRendererNames(), ", "),
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"ember/internal/service"
+)
+
+func runDownload(args []string) error {
+	fs := flag.NewFlagSet("download", flag.ExitOnError)
+	dir := fs.String("dir", "", "download directory (saved as the new default)")
+	template := fs.String("template", "", "episode path template, e.g. \"{series}/S{season}/S{season}E{episode} - {title}\" (saved as the new default)")
+	fs.Usage = func() {
+		fmt.Println("Usage: ember download [-dir path] [-template pattern] <item-id | name>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	query := strings.Join(fs.Args(), " ")
+	if query == "" {
+		fs.Usage()
+		return fmt.Errorf("missing item id or name")
+	}
+
+	svc, err := newService()
+	if err != nil {
+		return err
+	}
+	if svc.GetActiveServer() == nil {
+		return fmt.Errorf("no server configured; add one in the TUI first")
+	}
+	if *dir != "" {
+		svc.SetDownloadDir(*dir)
+	}
+	if *template != "" {
+		if err := svc.SetDownloadTemplate(*template); err != nil {
+			return err
+		}
+	}
+
+	item, err := svc.ResolveItem(query)
+	if err != nil {
+		return err
+	}
+
+	if item.Type == "Season" || item.Type == "Series" {
+		episodes, err := svc.EpisodesToDownload(*item)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Downloading %d episode(s) of %s to %s\n", len(episodes), displayTitle(*item), svc.DownloadDir())
+		for _, ep := range episodes {
+			fmt.Println(displayTitle(ep))
+			if err := downloadItem(svc, ep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	fmt.Printf("Downloading %s [%s] to %s\n", displayTitle(*item), item.ID, svc.DownloadDir())
+	return downloadItem(svc, *item)
+}
+
+func downloadItem(svc *service.MediaService, item service.MediaItem) error {
+	var last time.Time
+	d, err := svc.Download(context.Background(), item.ID, func(written, total int64) {
+		if time.Since(last) < 200*time.Millisecond && written != total {
+			return
+		}
+		last = time.Now()
+		fmt.Printf("\r%s", formatProgress(written, total))
+	})
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved %s\n", d.Path)
+	return nil
+}
+
+func formatProgress(written, total int64) string {
+	if total <= 0 {
+		return formatBytes(written)
+	}
+	return fmt.Sprintf("%5.1f%%  %s / %s", float64(written)*100/float64(total), formatBytes(written), formatBytes(total))
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -48,11 +48,36 @@ type MediaItem struct {
 	ParentBackdropItemID  string        `json:"ParentBackdropItemId,omitempty"`
 	ParentBackdropTags    []string      `json:"ParentBackdropImageTags,omitempty"`
 	IndexNumber           int           `json:"IndexNumber,omitempty"`
+	IndexNumberEnd        int           `json:"IndexNumberEnd,omitempty"`
+	ParentIndexNumber     int           `json:"ParentIndexNumber,omitempty"`
+	ChildCount            int           `json:"ChildCount,omitempty"`
+	MovieCount            int           `json:"MovieCount,omitempty"`
+	SeriesCount           int           `json:"SeriesCount,omitempty"`
+	PremiereDate          string        `json:"PremiereDate,omitempty"`
+	Status                string        `json:"Status,omitempty"`
+	Album                 string        `json:"Album,omitempty"`
+	AlbumArtist           string        `json:"AlbumArtist,omitempty"`
+	AlbumID               string        `json:"AlbumId,omitempty"`
 	RunTimeTicks          int64         `json:"RunTimeTicks,omitempty"`
+	CommunityRating       float64       `json:"CommunityRating,omitempty"`
 	MediaSources          []MediaSource `json:"MediaSources,omitempty"`
 	ImageTags             ImageTags     `json:"ImageTags,omitempty"`
 	BackdropImageTags     []string      `json:"BackdropImageTags,omitempty"`
 	UserData              *UserData     `json:"UserData,omitempty"`
+	Chapters              []Chapter     `json:"Chapters,omitempty"`
+	Genres                []string      `json:"Genres,omitempty"`
+	Number                string        `json:"Number,omitempty"`
+	ChannelID             string        `json:"ChannelId,omitempty"`
+	EpisodeTitle          string        `json:"EpisodeTitle,omitempty"`
+	StartDate             string        `json:"StartDate,omitempty"`
+	EndDate               string        `json:"EndDate,omitempty"`
+	CurrentProgram        *MediaItem    `json:"CurrentProgram,omitempty"`
+}
+
+type Chapter struct {
+	Name               string `json:"Name"`
+	StartPositionTicks int64  `json:"StartPositionTicks"`
+	MarkerType         string `json:"MarkerType,omitempty"`
 }
 
 type UserData struct {
@@ -60,18 +85,30 @@ type UserData struct {
 	Played                bool   `json:"Played"`
 	IsFavorite            bool   `json:"IsFavorite"`
 	LastPlayedDate        string `json:"LastPlayedDate,omitempty"`
+	PlayCount             int    `json:"PlayCount,omitempty"`
+	UnplayedItemCount     int    `json:"UnplayedItemCount,omitempty"`
+	Likes                 *bool  `json:"Likes,omitempty"`
 }
 
 type ImageTags struct {
 	Primary string `json:"Primary,omitempty"`
 	Thumb   string `json:"Thumb,omitempty"`
+	Logo    string `json:"Logo,omitempty"`
 }
 
 type MediaSource struct {
-	Protocol     string        `json:"Protocol,omitempty"`
-	ID           string        `json:"Id"`
-	Container    string        `json:"Container"`
-	MediaStreams []MediaStream `json:"MediaStreams,omitempty"`
+	Protocol             string        `json:"Protocol,omitempty"`
+	ID                   string        `json:"Id"`
+	Name                 string        `json:"Name,omitempty"`
+	Container            string        `json:"Container"`
+	Size                 int64         `json:"Size,omitempty"`
+	Bitrate              int64         `json:"Bitrate,omitempty"`
+	MediaStreams         []MediaStream `json:"MediaStreams,omitempty"`
+	SupportsDirectPlay   bool          `json:"SupportsDirectPlay,omitempty"`
+	SupportsDirectStream bool          `json:"SupportsDirectStream,omitempty"`
+	SupportsTranscoding  bool          `json:"SupportsTranscoding,omitempty"`
+	TranscodingURL       string        `json:"TranscodingUrl,omitempty"`
+	LiveStreamID         string        `json:"LiveStreamId,omitempty"`
 }
 
 type MediaStream struct {
@@ -83,6 +120,10 @@ type MediaStream struct {
 	IsExternal   bool   `json:"IsExternal"`
 	IsDefault    bool   `json:"IsDefault"`
 	Codec        string `json:"Codec,omitempty"`
+	Height       int    `json:"Height,omitempty"`
+	Width        int    `json:"Width,omitempty"`
+	VideoRange   string `json:"VideoRange,omitempty"`
+	Channels     int    `json:"Channels,omitempty"`
 }
 
 type ItemsResponse struct {
@@ -90,6 +131,32 @@ type ItemsResponse struct {
 	TotalCount int         `json:"TotalRecordCount"`
 }
 
+type PlaybackInfoResponse struct {
+	MediaSources  []MediaSource `json:"MediaSources"`
+	PlaySessionID string        `json:"PlaySessionId,omitempty"`
+	ErrorCode     string        `json:"ErrorCode,omitempty"`
+}
+
+type SearchHint struct {
+	ID          string `json:"Id,omitempty"`
+	ItemID      string `json:"ItemId,omitempty"`
+	Name        string `json:"Name"`
+	Type        string `json:"Type"`
+	MatchedTerm string `json:"MatchedTerm,omitempty"`
+}
+
+func (h SearchHint) EntityID() string {
+	if h.ItemID != "" {
+		return h.ItemID
+	}
+	return h.ID
+}
+
+type SearchHintsResponse struct {
+	SearchHints []SearchHint `json:"SearchHints"`
+	TotalCount  int          `json:"TotalRecordCount"`
+}
+
 type AuthResponse struct {
 	User        AuthUser `json:"User"`
 	AccessToken string   `json:"AccessToken"`
@@ -100,6 +167,14 @@ type AuthUser struct {
 	Name string `json:"Name"`
 }
 
+type ItemQuery struct {
+	SortBy    string
+	SortOrder string
+	Filters   []string
+	Genres    []string
+	Years     []int
+}
+
 type SearchOptions struct {
 	Query        string
 	Start        int
@@ -169,12 +244,12 @@ func (c *Client) request(ctx context.Context, method, endpoint string, body inte
 		return nil, err
 	}
 
-	if logging.IsEnabled() {
+	if logging.CategoryEnabled(logging.CategoryHTTP) {
 		logging.HTTP(method, c.Server+endpoint, resp.StatusCode, string(respBody))
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+		return nil, newError(resp, respBody)
 	}
 
 	return respBody, nil
@@ -222,12 +297,30 @@ func (c *Client) getItems(endpoint string) ([]MediaItem, error) {
 	return resp.Items, nil
 }
 
+func (c *Client) getItemsPage(endpoint string) ([]MediaItem, int, error) {
+	data, err := c.request(context.Background(), "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, 0, err
+	}
+	return resp.Items, resp.TotalCount, nil
+}
+
 func (c *Client) GetLibraries() ([]MediaItem, error) {
 	return c.getItems("/emby/Users/" + c.UserID + "/Views")
 }
 
-func (c *Client) GetLatest(limit int) ([]MediaItem, error) {
+func (c *Client) GetLatest(parentID string, limit int) ([]MediaItem, error) {
 	params := baseParams(limit)
+	params.Set("Fields", "Overview,MediaSources,ProductionYear,UserData")
+	params.Set("GroupItems", "true")
+	if parentID != "" {
+		params.Set("ParentId", parentID)
+	}
 	endpoint := fmt.Sprintf("/emby/Users/%s/Items/Latest?%s", c.UserID, params.Encode())
 
 	data, err := c.request(context.Background(), "GET", endpoint, nil)
@@ -248,27 +341,38 @@ func (c *Client) GetResume(limit int) ([]MediaItem, error) {
 	return c.getItems(endpoint)
 }
 
-func (c *Client) GetItems(parentID string, start, limit int) ([]MediaItem, int, error) {
+func (c *Client) GetItems(parentID string, start, limit int, query ItemQuery) ([]MediaItem, int, error) {
+	if query.SortBy == "" {
+		query.SortBy = "SortName"
+	}
+	if query.SortOrder == "" {
+		query.SortOrder = "Ascending"
+	}
+
 	params := baseParams(limit)
 	params.Set("Recursive", "true")
-	params.Set("SortBy", "SortName")
-	params.Set("SortOrder", "Ascending")
+	params.Set("SortBy", query.SortBy)
+	params.Set("SortOrder", query.SortOrder)
 	params.Set("StartIndex", fmt.Sprintf("%d", start))
 	if parentID != "" {
 		params.Set("ParentId", parentID)
 	}
-
-	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
-	data, err := c.request(context.Background(), "GET", endpoint, nil)
-	if err != nil {
-		return nil, 0, err
+	if len(query.Filters) > 0 {
+		params.Set("Filters", strings.Join(query.Filters, ","))
 	}
-
-	var resp ItemsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, 0, err
+	if len(query.Genres) > 0 {
+		params.Set("Genres", strings.Join(query.Genres, "|"))
 	}
-	return resp.Items, resp.TotalCount, nil
+	if len(query.Years) > 0 {
+		years := make([]string, len(query.Years))
+		for i, year := range query.Years {
+			years[i] = fmt.Sprintf("%d", year)
+		}
+		params.Set("Years", strings.Join(years, ","))
+	}
+
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItemsPage(endpoint)
 }
 
 func (c *Client) Search(query string, limit int) ([]MediaItem, error) {
@@ -317,21 +421,146 @@ func (c *Client) SearchWithOptions(opts SearchOptions) ([]MediaItem, int, error)
 	}
 
 	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) SearchHints(query string, limit int) ([]SearchHint, error) {
+	params := url.Values{
+		"SearchTerm":     {query},
+		"UserId":         {c.UserID},
+		"Limit":          {fmt.Sprintf("%d", limit)},
+		"IncludePeople":  {"true"},
+		"IncludeStudios": {"true"},
+		"IncludeGenres":  {"false"},
+		"IncludeMedia":   {"false"},
+	}
+
+	endpoint := "/emby/Search/Hints?" + params.Encode()
 	data, err := c.request(context.Background(), "GET", endpoint, nil)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	var resp ItemsResponse
+	var resp SearchHintsResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	return resp.Items, resp.TotalCount, nil
+	return resp.SearchHints, nil
+}
+
+func (c *Client) GetItemsByPerson(personID string, limit int) ([]MediaItem, error) {
+	return c.getLinkedItems("PersonIds", personID, limit)
+}
+
+func (c *Client) GetItemsByStudio(studioID string, limit int) ([]MediaItem, error) {
+	return c.getLinkedItems("StudioIds", studioID, limit)
+}
+
+func (c *Client) getLinkedItems(key, id string, limit int) ([]MediaItem, error) {
+	params := baseParams(limit)
+	params.Set("Recursive", "true")
+	params.Set("Fields", "Overview,MediaSources,ProductionYear,UserData")
+	params.Set("IncludeItemTypes", "Movie,Series")
+	params.Set("SortBy", "ProductionYear,SortName")
+	params.Set("SortOrder", "Descending")
+	params.Set(key, id)
+
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItems(endpoint)
+}
+
+func (c *Client) GetSimilarItems(itemID string, limit int) ([]MediaItem, error) {
+	params := baseParams(limit)
+	params.Set("UserId", c.UserID)
+	params.Set("Fields", "Overview,MediaSources,ProductionYear,UserData")
+
+	endpoint := fmt.Sprintf("/emby/Items/%s/Similar?%s", itemID, params.Encode())
+	return c.getItems(endpoint)
+}
+
+func (c *Client) GetStudios(start, limit int) ([]MediaItem, int, error) {
+	params := url.Values{
+		"UserId":           {c.UserID},
+		"Recursive":        {"true"},
+		"IncludeItemTypes": {"Movie,Series"},
+		"SortBy":           {"SortName"},
+		"SortOrder":        {"Ascending"},
+		"StartIndex":       {fmt.Sprintf("%d", start)},
+		"Limit":            {fmt.Sprintf("%d", limit)},
+		"EnableImageTypes": {"Primary,Thumb,Logo"},
+		"ImageTypeLimit":   {"1"},
+	}
+
+	endpoint := "/emby/Studios?" + params.Encode()
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) GetStudioItems(studioID string, start, limit int) ([]MediaItem, int, error) {
+	params := baseParams(limit)
+	params.Set("Recursive", "true")
+	params.Set("StartIndex", fmt.Sprintf("%d", start))
+	params.Set("Fields", "Overview,MediaSources,ProductionYear,UserData")
+	params.Set("IncludeItemTypes", "Movie,Series")
+	params.Set("SortBy", "SortName")
+	params.Set("SortOrder", "Ascending")
+	params.Set("StudioIds", studioID)
+
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) GetGenres(parentID string, start, limit int) ([]MediaItem, int, error) {
+	params := url.Values{
+		"UserId":           {c.UserID},
+		"Recursive":        {"true"},
+		"IncludeItemTypes": {"Movie,Series"},
+		"SortBy":           {"SortName"},
+		"SortOrder":        {"Ascending"},
+		"StartIndex":       {fmt.Sprintf("%d", start)},
+		"Limit":            {fmt.Sprintf("%d", limit)},
+		"Fields":           {"ItemCounts"},
+		"EnableImageTypes": {"Primary,Thumb"},
+		"ImageTypeLimit":   {"1"},
+	}
+	if parentID != "" {
+		params.Set("ParentId", parentID)
+	}
+
+	endpoint := "/emby/Genres?" + params.Encode()
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) GetGenreItems(parentID, genreID string, start, limit int) ([]MediaItem, int, error) {
+	params := baseParams(limit)
+	params.Set("Recursive", "true")
+	params.Set("StartIndex", fmt.Sprintf("%d", start))
+	params.Set("Fields", "Overview,MediaSources,ProductionYear,UserData")
+	params.Set("IncludeItemTypes", "Movie,Series")
+	params.Set("SortBy", "SortName")
+	params.Set("SortOrder", "Ascending")
+	params.Set("GenreIds", genreID)
+	if parentID != "" {
+		params.Set("ParentId", parentID)
+	}
+
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) GetOverviewCandidates(limit int) ([]MediaItem, error) {
+	params := baseParams(limit)
+	params.Set("Recursive", "true")
+	params.Set("Fields", "Overview,ProductionYear,UserData")
+	params.Set("IncludeItemTypes", "Movie,Series")
+	params.Set("SortBy", "SortName")
+
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItems(endpoint)
 }
 
 func (c *Client) GetItem(itemID string) (*MediaItem, error) {
 	params := url.Values{
-		"Fields": {"MediaSources,Overview,UserData"},
+		"Fields": {"MediaSources,Overview,UserData,Chapters,Genres"},
 	}
 
 	endpoint := fmt.Sprintf("/emby/Users/%s/Items/%s?%s", c.UserID, itemID, params.Encode())
@@ -348,20 +577,84 @@ func (c *Client) GetItem(itemID string) (*MediaItem, error) {
 }
 
 func (c *Client) GetSeasons(seriesID string) ([]MediaItem, error) {
-	endpoint := fmt.Sprintf("/emby/Shows/%s/Seasons?UserId=%s", seriesID, c.UserID)
+	endpoint := fmt.Sprintf("/emby/Shows/%s/Seasons?UserId=%s&Fields=ChildCount", seriesID, c.UserID)
 	return c.getItems(endpoint)
 }
 
-func (c *Client) GetEpisodes(seriesID, seasonID string) ([]MediaItem, error) {
+func (c *Client) GetEpisodes(seriesID, seasonID string, unplayedOnly bool) ([]MediaItem, error) {
 	params := url.Values{
-		"UserId":   {c.UserID},
-		"SeasonId": {seasonID},
-		"Fields":   {"MediaSources,Overview"},
+		"UserId": {c.UserID},
+		"Fields": {"MediaSources,Overview"},
+	}
+	if seasonID != "" {
+		params.Set("SeasonId", seasonID)
+	}
+	if unplayedOnly {
+		params.Set("Filters", "IsUnplayed")
 	}
 	endpoint := fmt.Sprintf("/emby/Shows/%s/Episodes?%s", seriesID, params.Encode())
 	return c.getItems(endpoint)
 }
 
+func (c *Client) GetPlaylistItems(playlistID string) ([]MediaItem, error) {
+	params := url.Values{
+		"UserId": {c.UserID},
+		"Fields": {"MediaSources,ProductionYear"},
+	}
+	endpoint := fmt.Sprintf("/emby/Playlists/%s/Items?%s", playlistID, params.Encode())
+	return c.getItems(endpoint)
+}
+
+// GetPlaybackInfo negotiates how to play itemID. With openLive the server
+// also opens a live stream (tunes a channel), which must be closed again
+// with CloseLiveStream.
+func (c *Client) GetPlaybackInfo(itemID, mediaSourceID string, maxBitrate, audioStreamIndex int, openLive bool) (*PlaybackInfoResponse, error) {
+	params := url.Values{
+		"UserId":     {c.UserID},
+		"IsPlayback": {"true"},
+	}
+	if mediaSourceID != "" {
+		params.Set("MediaSourceId", mediaSourceID)
+	}
+	if maxBitrate > 0 {
+		params.Set("MaxStreamingBitrate", fmt.Sprintf("%d", maxBitrate))
+	}
+	if audioStreamIndex >= 0 {
+		params.Set("AudioStreamIndex", fmt.Sprintf("%d", audioStreamIndex))
+	}
+
+	body := map[string]any{
+		"DeviceProfile": mpvDeviceProfile(maxBitrate),
+	}
+	if openLive {
+		body["AutoOpenLiveStream"] = true
+	}
+	endpoint := fmt.Sprintf("/emby/Items/%s/PlaybackInfo?%s", itemID, params.Encode())
+	data, err := c.request(context.Background(), "POST", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PlaybackInfoResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ErrorCode != "" {
+		return nil, &Error{Code: resp.ErrorCode}
+	}
+	return &resp, nil
+}
+
+func (c *Client) TranscodeURL(transcodingURL string) string {
+	if strings.HasPrefix(transcodingURL, "http://") || strings.HasPrefix(transcodingURL, "https://") {
+		return transcodingURL
+	}
+	if strings.HasPrefix(strings.ToLower(transcodingURL), "/emby/") {
+		return c.Server + transcodingURL
+	}
+	return c.Server + "/emby" + transcodingURL
+}
+
 func (c *Client) StreamURL(itemID, sourceID, container string) string {
 	return fmt.Sprintf("%s/emby/Videos/%s/stream.%s?MediaSourceId=%s&api_key=%s&Static=true",
 		c.Server, itemID, container, sourceID, c.Token)
@@ -382,31 +675,94 @@ func (c *Client) SubtitleURL(itemID, sourceID string, index int, codec string) s
 		c.Server, itemID, sourceID, index, ext, c.Token)
 }
 
+func (c *Client) ProbeStream(streamURL string) error {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", streamURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Emby-Authorization", c.authHeader())
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return newError(resp, body)
+	}
+	return nil
+}
+
+type SystemInfo struct {
+	ServerName        string `json:"ServerName"`
+	Version           string `json:"Version"`
+	HasPendingRestart bool   `json:"HasPendingRestart"`
+}
+
+func (c *Client) GetSystemInfo() (*SystemInfo, error) {
+	data, err := c.request(context.Background(), "GET", "/emby/System/Info", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info SystemInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+type PublicSystemInfo struct {
+	ServerName string `json:"ServerName"`
+	Version    string `json:"Version"`
+	ID         string `json:"Id"`
+}
+
+func (c *Client) GetPublicInfo() (*PublicSystemInfo, error) {
+	data, err := c.request(context.Background(), "GET", "/emby/System/Info/Public", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info PublicSystemInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
 func (c *Client) Ping() time.Duration {
 	start := time.Now()
 	c.request(context.Background(), "GET", "/emby/System/Info/Public", nil)
 	return time.Since(start)
 }
 
-func (c *Client) playbackBody(itemID, mediaSourceID, playSessionID string, positionTicks int64) map[string]any {
-	return map[string]any{
+func (c *Client) playbackBody(itemID, mediaSourceID, liveStreamID, playSessionID string, positionTicks int64) map[string]any {
+	body := map[string]any{
 		"ItemId":        itemID,
 		"MediaSourceId": mediaSourceID,
 		"PlaySessionId": playSessionID,
 		"PositionTicks": positionTicks,
 	}
+	if liveStreamID != "" {
+		body["LiveStreamId"] = liveStreamID
+	}
+	return body
 }
 
-func (c *Client) ReportPlaybackStart(itemID, mediaSourceID, playSessionID string, positionTicks int64) error {
-	body := c.playbackBody(itemID, mediaSourceID, playSessionID, positionTicks)
+func (c *Client) ReportPlaybackStart(itemID, mediaSourceID, liveStreamID, playSessionID string, positionTicks int64) error {
+	body := c.playbackBody(itemID, mediaSourceID, liveStreamID, playSessionID, positionTicks)
 	body["CanSeek"] = true
 	body["PlayMethod"] = "DirectStream"
 	_, err := c.request(context.Background(), "POST", "/emby/Sessions/Playing", body)
 	return err
 }
 
-func (c *Client) ReportPlaybackProgress(itemID, mediaSourceID, playSessionID string, positionTicks int64, isPaused bool) error {
-	body := c.playbackBody(itemID, mediaSourceID, playSessionID, positionTicks)
+func (c *Client) ReportPlaybackProgress(itemID, mediaSourceID, liveStreamID, playSessionID string, positionTicks int64, isPaused bool) error {
+	body := c.playbackBody(itemID, mediaSourceID, liveStreamID, playSessionID, positionTicks)
 	body["CanSeek"] = true
 	body["PlayMethod"] = "DirectStream"
 	body["IsPaused"] = isPaused
@@ -414,8 +770,8 @@ func (c *Client) ReportPlaybackProgress(itemID, mediaSourceID, playSessionID str
 	return err
 }
 
-func (c *Client) ReportPlaybackStopped(itemID, mediaSourceID, playSessionID string, positionTicks int64) error {
-	body := c.playbackBody(itemID, mediaSourceID, playSessionID, positionTicks)
+func (c *Client) ReportPlaybackStopped(itemID, mediaSourceID, liveStreamID, playSessionID string, positionTicks int64) error {
+	body := c.playbackBody(itemID, mediaSourceID, liveStreamID, playSessionID, positionTicks)
 	_, err := c.request(context.Background(), "POST", "/emby/Sessions/Playing/Stopped", body)
 	return err
 }
@@ -426,22 +782,73 @@ func (c *Client) AddFavorite(itemID string) error {
 	return err
 }
 
-func isHTTPStatusError(err error, status int) bool {
+func (c *Client) RemoveFavorite(itemID string) error {
+	endpoint := fmt.Sprintf("/emby/Users/%s/FavoriteItems/%s", c.UserID, itemID)
+	_, err := c.request(context.Background(), "DELETE", endpoint, nil)
 	if err == nil {
-		return false
+		return nil
+	}
+
+	if !IsStatus(err, http.StatusMethodNotAllowed) &&
+		!IsStatus(err, http.StatusNotFound) {
+		return err
+	}
+
+	legacyEndpoint := endpoint + "/Delete"
+	_, legacyErr := c.request(context.Background(), "POST", legacyEndpoint, nil)
+	if legacyErr != nil {
+		return errors.Join(err, legacyErr)
 	}
-	return strings.Contains(err.Error(), fmt.Sprintf("HTTP %d:", status))
+	return nil
 }
 
-func (c *Client) RemoveFavorite(itemID string) error {
-	endpoint := fmt.Sprintf("/emby/Users/%s/FavoriteItems/%s", c.UserID, itemID)
+func (c *Client) SetLikes(itemID string, likes bool) error {
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items/%s/Rating?Likes=%t", c.UserID, itemID, likes)
+	_, err := c.request(context.Background(), "POST", endpoint, nil)
+	return err
+}
+
+func (c *Client) ClearRating(itemID string) error {
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items/%s/Rating", c.UserID, itemID)
 	_, err := c.request(context.Background(), "DELETE", endpoint, nil)
 	if err == nil {
 		return nil
 	}
 
-	if !isHTTPStatusError(err, http.StatusMethodNotAllowed) &&
-		!isHTTPStatusError(err, http.StatusNotFound) {
+	if !IsStatus(err, http.StatusMethodNotAllowed) &&
+		!IsStatus(err, http.StatusNotFound) {
+		return err
+	}
+
+	_, legacyErr := c.request(context.Background(), "POST", endpoint+"/Delete", nil)
+	if legacyErr != nil {
+		return errors.Join(err, legacyErr)
+	}
+	return nil
+}
+
+func (c *Client) MarkPlayed(itemID string) error {
+	endpoint := fmt.Sprintf("/emby/Users/%s/PlayedItems/%s", c.UserID, itemID)
+	_, err := c.request(context.Background(), "POST", endpoint, nil)
+	return err
+}
+
+func (c *Client) SetPlaybackPosition(itemID string, positionTicks int64) error {
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items/%s/UserData", c.UserID, itemID)
+	body := map[string]interface{}{"PlaybackPositionTicks": positionTicks}
+	_, err := c.request(context.Background(), "POST", endpoint, body)
+	return err
+}
+
+func (c *Client) MarkUnplayed(itemID string) error {
+	endpoint := fmt.Sprintf("/emby/Users/%s/PlayedItems/%s", c.UserID, itemID)
+	_, err := c.request(context.Background(), "DELETE", endpoint, nil)
+	if err == nil {
+		return nil
+	}
+
+	if !IsStatus(err, http.StatusMethodNotAllowed) &&
+		!IsStatus(err, http.StatusNotFound) {
 		return err
 	}
 
@@ -468,21 +875,49 @@ func (c *Client) IsFavorite(itemID string) (bool, error) {
 	return len(items) > 0, nil
 }
 
-func (c *Client) GetFavorites(limit int) ([]MediaItem, error) {
+func (c *Client) GetFavorites(start, limit int, unplayedOnly bool) ([]MediaItem, int, error) {
 	params := baseParams(limit)
+	params.Set("StartIndex", fmt.Sprintf("%d", start))
 	params.Set("Recursive", "true")
 	params.Set("Fields", "Overview,MediaSources,ProductionYear,UserData")
 	params.Set("Filters", "IsFavorite")
+	if unplayedOnly {
+		params.Set("Filters", "IsFavorite,IsUnplayed")
+	}
 	params.Set("SortBy", "DatePlayed")
 	params.Set("SortOrder", "Descending")
 	params.Set("IncludeItemTypes", "Movie,Series,Episode")
 
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) GetRandomItems(parentID string, favorites, unplayed bool, limit int) ([]MediaItem, error) {
+	params := baseParams(limit)
+	params.Set("Recursive", "true")
+	params.Set("IncludeItemTypes", "Movie,Episode")
+	params.Set("SortBy", "Random")
+	if parentID != "" {
+		params.Set("ParentId", parentID)
+	}
+	var filters []string
+	if favorites {
+		filters = append(filters, "IsFavorite")
+	}
+	if unplayed {
+		filters = append(filters, "IsUnplayed")
+	}
+	if len(filters) > 0 {
+		params.Set("Filters", strings.Join(filters, ","))
+	}
+
 	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
 	return c.getItems(endpoint)
 }
 
-func (c *Client) GetResumeItems(limit int) ([]MediaItem, error) {
+func (c *Client) GetResumeItems(start, limit int) ([]MediaItem, int, error) {
 	params := baseParams(limit)
+	params.Set("StartIndex", fmt.Sprintf("%d", start))
 	params.Set("Recursive", "true")
 	params.Set("Fields", "Overview,MediaSources,ProductionYear,UserData")
 	params.Set("Filters", "IsResumable")
@@ -490,10 +925,64 @@ func (c *Client) GetResumeItems(limit int) ([]MediaItem, error) {
 	params.Set("SortOrder", "Descending")
 	params.Set("IncludeItemTypes", "Movie,Episode")
 
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) GetReleasedSince(since time.Time, start, limit int) ([]MediaItem, int, error) {
+	params := baseParams(limit)
+	params.Set("Recursive", "true")
+	params.Set("StartIndex", fmt.Sprintf("%d", start))
+	params.Set("Fields", "Overview,MediaSources,ProductionYear,PremiereDate,UserData")
+	params.Set("IncludeItemTypes", "Movie")
+	params.Set("MinPremiereDate", since.UTC().Format(time.RFC3339))
+	params.Set("MaxPremiereDate", time.Now().UTC().Format(time.RFC3339))
+	params.Set("SortBy", "PremiereDate,SortName")
+	params.Set("SortOrder", "Descending")
+
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	return c.getItemsPage(endpoint)
+}
+
+func (c *Client) GetAlbumTracks(albumID string) ([]MediaItem, error) {
+	params := baseParams(500)
+	params.Set("ParentId", albumID)
+	params.Set("Recursive", "true")
+	params.Set("IncludeItemTypes", "Audio")
+	params.Set("SortBy", "ParentIndexNumber,IndexNumber,SortName")
+	params.Set("SortOrder", "Ascending")
+
 	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
 	return c.getItems(endpoint)
 }
 
+func (c *Client) GetUpcoming(start, limit int) ([]MediaItem, int, error) {
+	params := baseParams(limit)
+	params.Set("UserId", c.UserID)
+	params.Set("StartIndex", fmt.Sprintf("%d", start))
+	params.Set("Fields", "Overview,ProductionYear,PremiereDate,UserData")
+
+	return c.getItemsPage("/emby/Shows/Upcoming?" + params.Encode())
+}
+
+func (c *Client) GetNextAiring(seriesID string) (*MediaItem, error) {
+	params := baseParams(1)
+	params.Set("ParentId", seriesID)
+	params.Set("Recursive", "true")
+	params.Set("IncludeItemTypes", "Episode")
+	params.Set("IsUnaired", "true")
+	params.Set("Fields", "PremiereDate")
+	params.Set("SortBy", "PremiereDate")
+	params.Set("SortOrder", "Ascending")
+
+	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
+	items, err := c.getItems(endpoint)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
 func (c *Client) GetHistory(start, limit int) ([]MediaItem, int, error) {
 	if limit <= 0 {
 		limit = 20
@@ -512,14 +1001,5 @@ func (c *Client) GetHistory(start, limit int) ([]MediaItem, int, error) {
 	params.Set("IncludeItemTypes", "Movie,Episode")
 
 	endpoint := fmt.Sprintf("/emby/Users/%s/Items?%s", c.UserID, params.Encode())
-	data, err := c.request(context.Background(), "GET", endpoint, nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	var resp ItemsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, 0, err
-	}
-	return resp.Items, resp.TotalCount, nil
+	return c.getItemsPage(endpoint)
 }
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type DownloadProgress func(written, total int64)
+
+func (c *Client) DownloadURL(itemID, mediaSourceID string) string {
+	url := fmt.Sprintf("%s/emby/Items/%s/Download?api_key=%s", c.Server, itemID, c.Token)
+	if mediaSourceID != "" {
+		url += "&MediaSourceId=" + mediaSourceID
+	}
+	return url
+}
+
+func (c *Client) Download(ctx context.Context, itemID, mediaSourceID, dest string, progress DownloadProgress) error {
+	partPath := dest + ".part"
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", partPath, err)
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("failed to seek %s: %w", partPath, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.DownloadURL(itemID, mediaSourceID), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Emby-Authorization", c.authHeader())
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	client := &http.Client{Transport: c.http.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		f.Close()
+		return os.Rename(partPath, dest)
+
+	case resp.StatusCode == http.StatusOK && offset > 0:
+		if err := f.Truncate(0); err != nil {
+			return fmt.Errorf("failed to restart download: %w", err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to restart download: %w", err)
+		}
+		offset = 0
+
+	case resp.StatusCode >= 400:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return newError(resp, body)
+	}
+
+	total := contentTotal(resp, offset)
+	w := &progressWriter{w: f, written: offset, total: total, progress: progress}
+	if progress != nil {
+		progress(offset, total)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to download: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", partPath, err)
+	}
+	return os.Rename(partPath, dest)
+}
+
+func contentTotal(resp *http.Response, offset int64) int64 {
+	if cr := resp.Header.Get("Content-Range"); cr != "" {
+		if idx := strings.LastIndex(cr, "/"); idx >= 0 {
+			if n, err := strconv.ParseInt(cr[idx+1:], 10, 64); err == nil {
+				return n
+			}
+		}
+	}
+	if resp.ContentLength > 0 {
+		return offset + resp.ContentLength
+	}
+	return 0
+}
+
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	progress DownloadProgress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.progress != nil {
+		p.progress(p.written, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode == 0 {
+		return "server error: " + e.Code
+	}
+	if e.Code != "" && e.Message == "" {
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Code)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *Error) Remedy() string {
+	code := strings.ToLower(e.Code)
+	msg := strings.ToLower(e.Message)
+
+	switch {
+	case code == "nocompatiblestream" || strings.Contains(msg, "transcod"):
+		return "Transcoding is disabled for this user; enable it in the Emby dashboard (Users > Media playback)"
+	case code == "ratelimitexceeded" || strings.Contains(msg, "bitrate"):
+		return "Server bitrate limit exceeded; lower the quality limit for this user"
+	case code == "notallowed" || e.StatusCode == http.StatusForbidden:
+		return "This user is not allowed to play this item; check playback permissions in the Emby dashboard"
+	case e.StatusCode == http.StatusUnauthorized:
+		return "Session expired; reconnect from server management (m)"
+	case e.StatusCode == http.StatusNotFound:
+		return "Item no longer exists on the server; refresh the view (r)"
+	case e.StatusCode >= http.StatusInternalServerError:
+		return "Server error; check the Emby server logs"
+	}
+	return ""
+}
+
+func newError(resp *http.Response, body []byte) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		Code:       resp.Header.Get("X-Application-Error-Code"),
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	var payload struct {
+		ErrorCode string `json:"ErrorCode"`
+		Message   string `json:"Message"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		if payload.ErrorCode != "" && e.Code == "" {
+			e.Code = payload.ErrorCode
+		}
+		if payload.Message != "" {
+			e.Message = payload.Message
+		}
+	}
+	return e
+}
+
+func IsStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+func Remedy(err error) string {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	return apiErr.Remedy()
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const liveTvDateFormat = "2006-01-02T15:04:05.0000000Z"
+
+func (c *Client) GetLiveTvChannels(start, limit int) ([]MediaItem, int, error) {
+	params := url.Values{
+		"UserId":             {c.UserID},
+		"StartIndex":         {fmt.Sprintf("%d", start)},
+		"Limit":              {fmt.Sprintf("%d", limit)},
+		"Fields":             {"MediaSources,Overview"},
+		"EnableImageTypes":   {"Primary,Thumb"},
+		"ImageTypeLimit":     {"1"},
+		"EnableUserData":     {"true"},
+		"AddCurrentProgram":  {"true"},
+		"SortBy":             {"DefaultChannelOrder"},
+		"SortOrder":          {"Ascending"},
+		"EnableFavoriteSort": {"true"},
+	}
+
+	return c.getItemsPage("/emby/LiveTv/Channels?" + params.Encode())
+}
+
+func (c *Client) GetLiveTvPrograms(channelIDs []string, from, to time.Time) ([]MediaItem, error) {
+	params := url.Values{
+		"UserId":       {c.UserID},
+		"ChannelIds":   {strings.Join(channelIDs, ",")},
+		"MinEndDate":   {from.UTC().Format(liveTvDateFormat)},
+		"MaxStartDate": {to.UTC().Format(liveTvDateFormat)},
+		"SortBy":       {"StartDate"},
+		"SortOrder":    {"Ascending"},
+		"Fields":       {"Overview"},
+		"Limit":        {"1000"},
+	}
+
+	return c.getItems("/emby/LiveTv/Programs?" + params.Encode())
+}
+
+func (c *Client) LiveStreamURL(itemID, sourceID, liveStreamID, container string) string {
+	return fmt.Sprintf("%s/emby/Videos/%s/stream.%s?MediaSourceId=%s&LiveStreamId=%s&api_key=%s&Static=true",
+		c.Server, itemID, container, sourceID, liveStreamID, c.Token)
+}
+
+// CloseLiveStream releases the tuner behind a live stream opened by
+// GetPlaybackInfo.
+func (c *Client) CloseLiveStream(liveStreamID string) error {
+	params := url.Values{"LiveStreamId": {liveStreamID}}
+	_, err := c.request(context.Background(), "POST", "/emby/LiveStreams/Close?"+params.Encode(), nil)
+	return err
+}
@@ -0,0 +1,61 @@
+package api
+
+const (
+	mpvContainers  = "mkv,mp4,m4v,mov,avi,webm,ts,m2ts,mpegts,flv,wmv,asf,mpg,mpeg,ogv,ogg,3gp"
+	mpvVideoCodecs = "h264,hevc,h265,av1,vp8,vp9,mpeg2video,mpeg4,msmpeg4v3,vc1,wmv3"
+	mpvAudioCodecs = "aac,mp3,mp2,ac3,eac3,flac,alac,opus,vorbis,dts,truehd,pcm_s16le,pcm_s24le,wmav2"
+)
+
+const defaultMaxBitrate = 200_000_000
+
+func mpvDeviceProfile(maxBitrate int) map[string]any {
+	if maxBitrate <= 0 {
+		maxBitrate = defaultMaxBitrate
+	}
+	return map[string]any{
+		"Name":                "Ember mpv",
+		"MaxStreamingBitrate": maxBitrate,
+		"MaxStaticBitrate":    maxBitrate,
+		"DirectPlayProfiles": []map[string]any{
+			{
+				"Type":       "Video",
+				"Container":  mpvContainers,
+				"VideoCodec": mpvVideoCodecs,
+				"AudioCodec": mpvAudioCodecs,
+			},
+			{
+				"Type": "Audio",
+			},
+		},
+		"TranscodingProfiles": []map[string]any{
+			{
+				"Type":                "Video",
+				"Container":           "ts",
+				"VideoCodec":          "h264",
+				"AudioCodec":          "aac,mp3,ac3",
+				"Protocol":            "hls",
+				"Context":             "Streaming",
+				"MaxAudioChannels":    "6",
+				"BreakOnNonKeyFrames": true,
+			},
+			{
+				"Type":       "Audio",
+				"Container":  "mp3",
+				"AudioCodec": "mp3",
+				"Protocol":   "http",
+				"Context":    "Streaming",
+			},
+		},
+		"SubtitleProfiles": []map[string]any{
+			{"Format": "srt", "Method": "External"},
+			{"Format": "ass", "Method": "External"},
+			{"Format": "ssa", "Method": "External"},
+			{"Format": "vtt", "Method": "External"},
+			{"Format": "srt", "Method": "Embed"},
+			{"Format": "ass", "Method": "Embed"},
+			{"Format": "ssa", "Method": "Embed"},
+			{"Format": "pgssub", "Method": "Embed"},
+			{"Format": "dvdsub", "Method": "Embed"},
+		},
+	}
+}
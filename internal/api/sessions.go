@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type Session struct {
+	ID               string     `json:"Id"`
+	UserID           string     `json:"UserId"`
+	UserName         string     `json:"UserName"`
+	Client           string     `json:"Client"`
+	DeviceName       string     `json:"DeviceName"`
+	DeviceID         string     `json:"DeviceId"`
+	LastActivityDate string     `json:"LastActivityDate,omitempty"`
+	NowPlayingItem   *MediaItem `json:"NowPlayingItem,omitempty"`
+	PlayState        *PlayState `json:"PlayState,omitempty"`
+
+	TranscodingInfo *TranscodingInfo `json:"TranscodingInfo,omitempty"`
+}
+
+type TranscodingInfo struct {
+	VideoCodec           string   `json:"VideoCodec,omitempty"`
+	AudioCodec           string   `json:"AudioCodec,omitempty"`
+	Container            string   `json:"Container,omitempty"`
+	IsVideoDirect        bool     `json:"IsVideoDirect"`
+	IsAudioDirect        bool     `json:"IsAudioDirect"`
+	Bitrate              int      `json:"Bitrate,omitempty"`
+	Framerate            float64  `json:"Framerate,omitempty"`
+	CompletionPercentage float64  `json:"CompletionPercentage,omitempty"`
+	IsThrottled          bool     `json:"IsThrottled,omitempty"`
+	TranscodeReasons     []string `json:"TranscodeReasons,omitempty"`
+}
+
+type PlayState struct {
+	PositionTicks int64  `json:"PositionTicks,omitempty"`
+	IsPaused      bool   `json:"IsPaused"`
+	PlayMethod    string `json:"PlayMethod,omitempty"`
+}
+
+func (s Session) IsCurrentDevice() bool {
+	return s.DeviceID == deviceID
+}
+
+func (c *Client) GetSessions() ([]Session, error) {
+	params := url.Values{"ControllableByUserId": {c.UserID}}
+	data, err := c.request(context.Background(), "GET", "/emby/Sessions?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []Session
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (c *Client) GetServerSessions() ([]Session, error) {
+	data, err := c.request(context.Background(), "GET", "/emby/Sessions?ActiveWithinSeconds=960", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []Session
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+type Capabilities struct {
+	PlayableMediaTypes   []string `json:"PlayableMediaTypes"`
+	SupportedCommands    []string `json:"SupportedCommands"`
+	SupportsMediaControl bool     `json:"SupportsMediaControl"`
+}
+
+func (c *Client) ReportCapabilities(caps Capabilities) error {
+	_, err := c.request(context.Background(), "POST", "/emby/Sessions/Capabilities/Full", caps)
+	return err
+}
+
+func (c *Client) PingPlayback(playSessionID string) error {
+	params := url.Values{"PlaySessionId": {playSessionID}}
+	_, err := c.request(context.Background(), "POST", "/emby/Sessions/Playing/Ping?"+params.Encode(), nil)
+	return err
+}
+
+func (c *Client) StopSession(sessionID string) error {
+	endpoint := fmt.Sprintf("/emby/Sessions/%s/Playing/Stop", sessionID)
+	_, err := c.request(context.Background(), "POST", endpoint, nil)
+	return err
+}
+
+func (c *Client) DeleteDevice(deviceID string) error {
+	params := url.Values{"Id": {deviceID}}
+	_, err := c.request(context.Background(), "DELETE", "/emby/Devices?"+params.Encode(), nil)
+	return err
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+type Config struct {
+	PageSize int               `toml:"page_size"`
+	Player   Player            `toml:"player"`
+	Theme    Theme             `toml:"theme"`
+	Images   Images            `toml:"images"`
+	Startup  Startup           `toml:"startup"`
+	Keys     map[string]string `toml:"keys"`
+}
+
+type Player struct {
+	Path    string   `toml:"path"`
+	Profile string   `toml:"profile"`
+	Args    []string `toml:"args"`
+}
+
+type Theme struct {
+	Accent string `toml:"accent"`
+}
+
+type Images struct {
+	Renderer string `toml:"renderer"`
+	Quality  string `toml:"quality"`
+}
+
+type Startup struct {
+	Skip       []string `toml:"skip"`
+	TimeoutSec int      `toml:"timeout_sec"`
+}
+
+var StartupChecks = []string{"config", "storage", "player", "server"}
+
+// Image qualities accepted under [images]; the ui package draws with
+// the same values.
+const (
+	ImageQualityOff  = "off"
+	ImageQualityLow  = "low"
+	ImageQualityHigh = "high"
+)
+
+var ImageQualities = []string{ImageQualityOff, ImageQualityLow, ImageQualityHigh}
+
+var (
+	homeDir, _ = os.UserHomeDir()
+
+	loadOnce sync.Once
+	loaded   Config
+	loadErr  error
+)
+
+func Path() string {
+	if path := os.Getenv("EMBER_CONFIG"); path != "" {
+		return path
+	}
+	return filepath.Join(homeDir, ".ember", "config.toml")
+}
+
+func Load() (Config, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = load(Path())
+	})
+	return loaded, loadErr
+}
+
+func load(path string) (Config, error) {
+	var cfg Config
+	meta, err := toml.DecodeFile(path, &cfg)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return Config{}, fmt.Errorf("failed to read %s: %w", path, err)
+	default:
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return Config{}, fmt.Errorf("unknown option %q in %s", undecoded[0].String(), path)
+		}
+	}
+
+	if err := applyEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if cfg.PageSize < 0 {
+		return Config{}, fmt.Errorf("page_size must be positive")
+	}
+	if cfg.Startup.TimeoutSec < 0 {
+		return Config{}, fmt.Errorf("startup.timeout_sec must be positive")
+	}
+	if cfg.Images.Quality != "" && !slices.Contains(ImageQualities, cfg.Images.Quality) {
+		return Config{}, fmt.Errorf("unknown image quality %q (expected one of %s)", cfg.Images.Quality, strings.Join(ImageQualities, ", "))
+	}
+	for _, name := range cfg.Startup.Skip {
+		if !slices.Contains(StartupChecks, name) {
+			return Config{}, fmt.Errorf("unknown startup check %q (expected one of %s)", name, strings.Join(StartupChecks, ", "))
+		}
+	}
+	return cfg, nil
+}
+
+func applyEnv(cfg *Config) error {
+	if value := os.Getenv("EMBER_PAGE_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid EMBER_PAGE_SIZE: %w", err)
+		}
+		cfg.PageSize = size
+	}
+	if value := os.Getenv("EMBER_MPV_PATH"); value != "" {
+		cfg.Player.Path = value
+	}
+	if value := os.Getenv("EMBER_MPV_PROFILE"); value != "" {
+		cfg.Player.Profile = value
+	}
+	if value := os.Getenv("EMBER_MPV_ARGS"); value != "" {
+		cfg.Player.Args = strings.Fields(value)
+	}
+	if value := os.Getenv("EMBER_ACCENT"); value != "" {
+		cfg.Theme.Accent = value
+	}
+	if value := os.Getenv("EMBER_RENDERER"); value != "" {
+		cfg.Images.Renderer = value
+	}
+	if value := os.Getenv("EMBER_IMAGE_QUALITY"); value != "" {
+		cfg.Images.Quality = value
+	}
+	return nil
+}
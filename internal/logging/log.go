@@ -3,20 +3,36 @@ package logging
 import (
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/charmbracelet/log"
 )
 
+type Category string
+
+const (
+	CategoryHTTP    Category = "http"
+	CategoryMPV     Category = "mpv"
+	CategoryUI      Category = "ui"
+	CategoryStorage Category = "storage"
+	CategoryImages  Category = "images"
+)
+
+var Categories = []Category{CategoryHTTP, CategoryMPV, CategoryUI, CategoryStorage, CategoryImages}
+
 var (
 	logger      *log.Logger
 	imageLogger *log.Logger
 	enabled     = true
 
+	mu       sync.RWMutex
+	disabled = make(map[Category]bool)
+
 	homeDir, _ = os.UserHomeDir()
 )
 
 func init() {
-	logDir := filepath.Join(homeDir, ".ember")
+	logDir := Dir()
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		return
 	}
@@ -44,6 +60,10 @@ func init() {
 	})
 }
 
+func Dir() string {
+	return filepath.Join(homeDir, ".ember")
+}
+
 func SetEnabled(v bool) {
 	enabled = v
 	if enabled {
@@ -67,8 +87,24 @@ func IsEnabled() bool {
 	return enabled
 }
 
+func SetCategoryEnabled(c Category, v bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	disabled[c] = !v
+}
+
+func CategoryEnabled(c Category) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return !disabled[c]
+}
+
+func active(c Category) bool {
+	return enabled && CategoryEnabled(c)
+}
+
 func MPV(path string, args []string) {
-	if !enabled || logger == nil {
+	if !active(CategoryMPV) || logger == nil {
 		return
 	}
 
@@ -79,7 +115,7 @@ func MPV(path string, args []string) {
 }
 
 func HTTP(method, url string, status int, body string) {
-	if !enabled || logger == nil {
+	if !active(CategoryHTTP) || logger == nil {
 		return
 	}
 
@@ -92,7 +128,7 @@ func HTTP(method, url string, status int, body string) {
 }
 
 func ImageError(url string, status int, contentType string, err error) {
-	if !enabled || imageLogger == nil || err == nil {
+	if !active(CategoryImages) || imageLogger == nil || err == nil {
 		return
 	}
 
@@ -103,3 +139,35 @@ func ImageError(url string, status int, contentType string, err error) {
 		"error", err.Error(),
 	)
 }
+
+func UI(msg string, keyvals ...any) {
+	if !active(CategoryUI) || logger == nil {
+		return
+	}
+
+	logger.Debug(msg, keyvals...)
+}
+
+func Storage(msg string, keyvals ...any) {
+	if !active(CategoryStorage) || logger == nil {
+		return
+	}
+
+	logger.Debug(msg, keyvals...)
+}
+
+func Player(msg string, keyvals ...any) {
+	if !active(CategoryMPV) || logger == nil {
+		return
+	}
+
+	logger.Debug(msg, keyvals...)
+}
+
+func Images(msg string, keyvals ...any) {
+	if !active(CategoryImages) || logger == nil {
+		return
+	}
+
+	logger.Debug(msg, keyvals...)
+}
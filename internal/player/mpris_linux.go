@@ -0,0 +1,228 @@
+//go:build linux
+
+package player
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+
+	"ember/internal/logging"
+)
+
+const (
+	mprisPath        = dbus.ObjectPath("/org/mpris/MediaPlayer2")
+	mprisRootIface   = "org.mpris.MediaPlayer2"
+	mprisPlayerIface = "org.mpris.MediaPlayer2.Player"
+	mprisTrackID     = dbus.ObjectPath("/org/ember/track/current")
+
+	// mprisSeekSlack is how far the position may drift from where one
+	// second of playback would put it before it counts as a seek.
+	mprisSeekSlack = 2
+)
+
+type mprisRoot struct {
+	ctrl *Controller
+}
+
+func (r mprisRoot) Raise() *dbus.Error {
+	return nil
+}
+
+func (r mprisRoot) Quit() *dbus.Error {
+	return dbusError(r.ctrl.Stop())
+}
+
+type mprisPlayer struct {
+	ctrl *Controller
+}
+
+func (p mprisPlayer) Next() *dbus.Error {
+	return dbusError(p.ctrl.Next())
+}
+
+func (p mprisPlayer) Previous() *dbus.Error {
+	return dbusError(p.ctrl.Previous())
+}
+
+func (p mprisPlayer) Pause() *dbus.Error {
+	return dbusError(p.ctrl.SetPaused(true))
+}
+
+func (p mprisPlayer) Play() *dbus.Error {
+	return dbusError(p.ctrl.SetPaused(false))
+}
+
+func (p mprisPlayer) PlayPause() *dbus.Error {
+	return dbusError(p.ctrl.TogglePause())
+}
+
+func (p mprisPlayer) Stop() *dbus.Error {
+	return dbusError(p.ctrl.Stop())
+}
+
+func (p mprisPlayer) SeekBy(offset int64) *dbus.Error {
+	return dbusError(p.ctrl.Seek(int(offset / 1_000_000)))
+}
+
+func (p mprisPlayer) SetPosition(trackID dbus.ObjectPath, position int64) *dbus.Error {
+	if trackID != mprisTrackID {
+		return nil
+	}
+	return dbusError(p.ctrl.SeekTo(position / 1_000_000))
+}
+
+func (p mprisPlayer) OpenUri(uri string) *dbus.Error {
+	return dbus.MakeFailedError(fmt.Errorf("not supported"))
+}
+
+func dbusError(err error) *dbus.Error {
+	if err == nil {
+		return nil
+	}
+	return dbus.MakeFailedError(err)
+}
+
+func startMPRIS(ctrl *Controller) func() {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		logging.Player("MPRIS unavailable", "error", err)
+		return func() {}
+	}
+
+	name := fmt.Sprintf("org.mpris.MediaPlayer2.ember.instance%d", os.Getpid())
+	reply, err := conn.RequestName(name, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		logging.Player("MPRIS name unavailable", "name", name, "error", err)
+		conn.Close()
+		return func() {}
+	}
+
+	root := mprisRoot{ctrl: ctrl}
+	player := mprisPlayer{ctrl: ctrl}
+	_ = conn.Export(root, mprisPath, mprisRootIface)
+	_ = conn.ExportWithMap(player, map[string]string{"SeekBy": "Seek"}, mprisPath, mprisPlayerIface)
+
+	props, err := prop.Export(conn, mprisPath, prop.Map{
+		mprisRootIface: {
+			"CanQuit":             {Value: true},
+			"CanRaise":            {Value: false},
+			"HasTrackList":        {Value: false},
+			"Identity":            {Value: "Ember"},
+			"SupportedUriSchemes": {Value: []string{}},
+			"SupportedMimeTypes":  {Value: []string{}},
+		},
+		mprisPlayerIface: {
+			"PlaybackStatus": {Value: "Playing", Emit: prop.EmitTrue},
+			"LoopStatus":     {Value: "None"},
+			"Rate":           {Value: 1.0},
+			"Shuffle":        {Value: false},
+			"Metadata":       {Value: mprisMetadata(ctrl), Emit: prop.EmitTrue},
+			"Volume":         {Value: 1.0},
+			"Position":       {Value: int64(0), Emit: prop.EmitFalse},
+			"MinimumRate":    {Value: 1.0},
+			"MaximumRate":    {Value: 1.0},
+			"CanGoNext":      {Value: true},
+			"CanGoPrevious":  {Value: true},
+			"CanPlay":        {Value: true},
+			"CanPause":       {Value: true},
+			"CanSeek":        {Value: true},
+			"CanControl":     {Value: true},
+		},
+	})
+	if err != nil {
+		logging.Player("MPRIS export failed", "error", err)
+		conn.Close()
+		return func() {}
+	}
+
+	playerMethods := introspect.Methods(player)
+	for i := range playerMethods {
+		if playerMethods[i].Name == "SeekBy" {
+			playerMethods[i].Name = "Seek"
+		}
+	}
+	node := &introspect.Node{
+		Name: string(mprisPath),
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			prop.IntrospectData,
+			{Name: mprisRootIface, Methods: introspect.Methods(root), Properties: props.Introspection(mprisRootIface)},
+			{
+				Name:       mprisPlayerIface,
+				Methods:    playerMethods,
+				Properties: props.Introspection(mprisPlayerIface),
+				Signals:    []introspect.Signal{{Name: "Seeked", Args: []introspect.Arg{{Name: "Position", Type: "x"}}}},
+			},
+		},
+	}
+	_ = conn.Export(introspect.NewIntrospectable(node), mprisPath, "org.freedesktop.DBus.Introspectable")
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		status, title := "", ""
+		var duration, position int64
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			paused := ctrl.Paused()
+			expected := position
+			if status == "Playing" {
+				expected++
+			}
+			position = ctrl.Position()
+			props.SetMust(mprisPlayerIface, "Position", position*1_000_000)
+
+			next := "Playing"
+			if paused {
+				next = "Paused"
+			}
+			if next != status {
+				status = next
+				props.SetMust(mprisPlayerIface, "PlaybackStatus", status)
+			}
+			// mpv reports the duration a moment after the title, so the
+			// metadata goes out again once mpris:length is known.
+			if ctrl.Title() != title || ctrl.Duration() != duration {
+				changedTrack := ctrl.Title() != title
+				title, duration = ctrl.Title(), ctrl.Duration()
+				props.SetMust(mprisPlayerIface, "Metadata", mprisMetadata(ctrl))
+				if changedTrack {
+					continue
+				}
+			}
+			// Position is not emitted on change; clients rely on Seeked
+			// to learn about jumps, whether from us or from mpv's keys.
+			if position-expected > mprisSeekSlack || expected-position > mprisSeekSlack {
+				_ = conn.Emit(mprisPath, mprisPlayerIface+".Seeked", position*1_000_000)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		conn.Close()
+	}
+}
+
+func mprisMetadata(ctrl *Controller) map[string]dbus.Variant {
+	meta := map[string]dbus.Variant{
+		"mpris:trackid": dbus.MakeVariant(mprisTrackID),
+		"xesam:title":   dbus.MakeVariant(ctrl.Title()),
+	}
+	if dur := ctrl.Duration(); dur > 0 {
+		meta["mpris:length"] = dbus.MakeVariant(dur * 1_000_000)
+	}
+	return meta
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package player
+
+func startMPRIS(ctrl *Controller) func() {
+	return func() {}
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -39,13 +41,178 @@ func findMPVPath() string {
 	return ""
 }
 
+func SetPath(path string) error {
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return fmt.Errorf("failed to find mpv at %s: %w", path, err)
+	}
+	mpvPath = resolved
+	return nil
+}
+
 func Available() bool {
 	return mpvPath != ""
 }
 
+func Version() string {
+	if mpvPath == "" {
+		return ""
+	}
+	out, err := exec.Command(mpvPath, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(string(out), "\n")
+	return strings.TrimSpace(line)
+}
+
 type PlayResult struct {
 	Err         error
 	PositionSec int64
+	Index       int
+}
+
+type SwitchFunc func(from, to int, positionSec int64)
+
+type Chapter struct {
+	Title    string
+	StartSec int64
+}
+
+type Metadata struct {
+	Title    string
+	Chapters []Chapter
+	Next     string
+	Audio    bool
+	TrackKey string
+	Tracks   *TrackChoice
+}
+
+type TrackChoice struct {
+	AudioLang    string
+	SubtitleLang string
+	SubtitlesOff bool
+}
+
+type TrackListener func(key string, choice TrackChoice)
+
+var trackListener atomic.Pointer[TrackListener]
+
+func SetTrackListener(fn TrackListener) {
+	trackListener.Store(&fn)
+}
+
+type Controller struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+
+	position atomic.Int64
+	duration atomic.Int64
+	index    atomic.Int64
+	paused   atomic.Bool
+	title    atomic.Pointer[string]
+
+	proc   *os.Process
+	exited chan struct{}
+}
+
+var current atomic.Pointer[Controller]
+
+type Args struct {
+	Profile        string
+	Extra          []string
+	SubtitleLangs  []string
+	AudioLangs     []string
+	ForcedSubsOnly bool
+}
+
+var userArgs atomic.Pointer[Args]
+
+func SetArgs(a Args) {
+	a.Extra = append([]string(nil), a.Extra...)
+	a.SubtitleLangs = append([]string(nil), a.SubtitleLangs...)
+	a.AudioLangs = append([]string(nil), a.AudioLangs...)
+	userArgs.Store(&a)
+}
+
+func Current() *Controller {
+	return current.Load()
+}
+
+func (c *Controller) Position() int64 {
+	return c.position.Load()
+}
+
+func (c *Controller) Duration() int64 {
+	return c.duration.Load()
+}
+
+func (c *Controller) Index() int {
+	return int(c.index.Load())
+}
+
+func (c *Controller) Paused() bool {
+	return c.paused.Load()
+}
+
+func (c *Controller) Title() string {
+	if title := c.title.Load(); title != nil {
+		return *title
+	}
+	return ""
+}
+
+func (c *Controller) Seek(deltaSec int) error {
+	return c.send("seek", deltaSec, "relative")
+}
+
+func (c *Controller) SeekTo(sec int64) error {
+	return c.send("seek", sec, "absolute")
+}
+
+func (c *Controller) TogglePause() error {
+	return c.send("cycle", "pause")
+}
+
+func (c *Controller) SetPaused(paused bool) error {
+	return c.send("set_property", "pause", paused)
+}
+
+func (c *Controller) Next() error {
+	return c.send("playlist-next")
+}
+
+func (c *Controller) Previous() error {
+	return c.send("playlist-prev")
+}
+
+func (c *Controller) Stop() error {
+	return c.send("quit")
+}
+
+func Shutdown(timeout time.Duration) {
+	ctrl := current.Load()
+	if ctrl == nil {
+		return
+	}
+	logging.Player("Stopping mpv for shutdown")
+	_ = ctrl.Stop()
+	select {
+	case <-ctrl.exited:
+	case <-time.After(timeout):
+		logging.Player("mpv did not quit, killing it", "pid", ctrl.proc.Pid)
+		_ = ctrl.proc.Kill()
+		<-ctrl.exited
+	}
+}
+
+func (c *Controller) send(args ...any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.enc == nil {
+		return fmt.Errorf("mpv ipc not connected")
+	}
+	return c.enc.Encode(map[string]any{"command": args})
 }
 
 type ipcEvent struct {
@@ -54,23 +221,31 @@ type ipcEvent struct {
 	Data  any    `json:"data"`
 }
 
-func Play(url, title string, subtitleURLs []string, startPositionSec int64) PlayResult {
-	return play([]string{url}, title, subtitleURLs, startPositionSec, 0, nil)
+func Play(url, title string, subtitleURLs []string, startPositionSec int64, meta ...Metadata) PlayResult {
+	return play([]string{url}, title, subtitleURLs, startPositionSec, 0, nil, nil, nil, meta)
+}
+
+func PlayWithHook(url, title string, subtitleURLs []string, startPositionSec int64, onStarted func(), meta ...Metadata) PlayResult {
+	return play([]string{url}, title, subtitleURLs, startPositionSec, 0, onStarted, nil, nil, meta)
 }
 
-func PlayWithHook(url, title string, subtitleURLs []string, startPositionSec int64, onStarted func()) PlayResult {
-	return play([]string{url}, title, subtitleURLs, startPositionSec, 0, onStarted)
+func PlayWithProgress(url, title string, subtitleURLs []string, startPositionSec int64, onStarted func(), onProgress func(positionSec int64), meta ...Metadata) PlayResult {
+	return play([]string{url}, title, subtitleURLs, startPositionSec, 0, onStarted, onProgress, nil, meta)
 }
 
-func PlayMultiple(urls []string, title string, subtitleURLs []string, startPositionSec int64, startIndex int) PlayResult {
-	return play(urls, title, subtitleURLs, startPositionSec, startIndex, nil)
+func PlayMultiple(urls []string, title string, subtitleURLs []string, startPositionSec int64, startIndex int, meta ...Metadata) PlayResult {
+	return play(urls, title, subtitleURLs, startPositionSec, startIndex, nil, nil, nil, meta)
 }
 
-func PlayMultipleWithHook(urls []string, title string, subtitleURLs []string, startPositionSec int64, startIndex int, onStarted func()) PlayResult {
-	return play(urls, title, subtitleURLs, startPositionSec, startIndex, onStarted)
+func PlayMultipleWithHook(urls []string, title string, subtitleURLs []string, startPositionSec int64, startIndex int, onStarted func(), meta ...Metadata) PlayResult {
+	return play(urls, title, subtitleURLs, startPositionSec, startIndex, onStarted, nil, nil, meta)
 }
 
-func play(urls []string, title string, subtitleURLs []string, startPositionSec int64, startIndex int, onStarted func()) PlayResult {
+func PlayMultipleTracked(urls []string, title string, subtitleURLs []string, startPositionSec int64, startIndex int, onStarted func(), onSwitch SwitchFunc, meta ...Metadata) PlayResult {
+	return play(urls, title, subtitleURLs, startPositionSec, startIndex, onStarted, nil, onSwitch, meta)
+}
+
+func play(urls []string, title string, subtitleURLs []string, startPositionSec int64, startIndex int, onStarted func(), onProgress func(int64), onSwitch SwitchFunc, meta []Metadata) PlayResult {
 	if mpvPath == "" {
 		return PlayResult{Err: exec.ErrNotFound}
 	}
@@ -82,7 +257,11 @@ func play(urls []string, title string, subtitleURLs []string, startPositionSec i
 	_ = os.Remove(ipcPath)
 	defer os.Remove(ipcPath)
 
-	args := buildMPVArgs(title, subtitleURLs, urls, startPositionSec, startIndex, ipcPath)
+	audio := startIndex < len(meta) && meta[startIndex].Audio
+	args := buildMPVArgs(title, subtitleURLs, urls, meta, startPositionSec, startIndex, ipcPath, audio)
+	if startIndex < len(meta) && meta[startIndex].Title != "" {
+		args = append([]string{"--force-media-title=" + meta[startIndex].Title}, args...)
+	}
 	logging.MPV(mpvPath, args)
 
 	cmd := exec.Command(mpvPath, args...)
@@ -97,18 +276,34 @@ func play(urls []string, title string, subtitleURLs []string, startPositionSec i
 		go onStarted()
 	}
 
-	var position atomic.Int64
-	position.Store(startPositionSec)
-	go observePlaybackPosition(ipcPath, &position)
+	ctrl := &Controller{proc: cmd.Process, exited: make(chan struct{})}
+	ctrl.position.Store(startPositionSec)
+	ctrl.index.Store(int64(startIndex))
+	ctrl.title.Store(&title)
+	if startIndex < len(meta) && meta[startIndex].Title != "" {
+		ctrl.title.Store(&meta[startIndex].Title)
+	}
+	current.Store(ctrl)
+	defer current.CompareAndSwap(ctrl, nil)
+	defer startMPRIS(ctrl)()
+	go observePlayback(ipcPath, ctrl, meta, onSwitch)
+
+	done := make(chan struct{})
+	defer close(done)
+	if onProgress != nil {
+		go reportProgress(&ctrl.position, onProgress, done)
+	}
 
 	runErr := cmd.Wait()
+	close(ctrl.exited)
 	return PlayResult{
 		Err:         runErr,
-		PositionSec: position.Load(),
+		PositionSec: ctrl.position.Load(),
+		Index:       ctrl.Index(),
 	}
 }
 
-func buildMPVArgs(title string, subtitleURLs, urls []string, startPositionSec int64, startIndex int, ipcPath string) []string {
+func buildMPVArgs(title string, subtitleURLs, urls []string, meta []Metadata, startPositionSec int64, startIndex int, ipcPath string, audio bool) []string {
 	args := []string{
 		"--hwdec=auto",
 		"--vo=gpu",
@@ -117,9 +312,27 @@ func buildMPVArgs(title string, subtitleURLs, urls []string, startPositionSec in
 		"--prefetch-playlist=yes",
 		"--terminal=no",
 		"--title=" + title,
-		"--slang=chi,zho,zh,chs,cht,cn,chinese",
 		"--input-ipc-server=" + ipcPath,
 	}
+	if audio {
+		args = append(args, "--no-video", "--force-window=no", "--fullscreen=no")
+	}
+
+	if user := userArgs.Load(); user != nil {
+		if len(user.SubtitleLangs) > 0 {
+			args = append(args, "--slang="+strings.Join(user.SubtitleLangs, ","))
+		}
+		if len(user.AudioLangs) > 0 {
+			args = append(args, "--alang="+strings.Join(user.AudioLangs, ","))
+		}
+		if user.ForcedSubsOnly {
+			args = append(args, "--subs-with-matching-audio=forced")
+		}
+		if user.Profile != "" {
+			args = append(args, "--profile="+user.Profile)
+		}
+		args = append(args, user.Extra...)
+	}
 
 	if startPositionSec > 0 {
 		args = append(args, fmt.Sprintf("--start=%d", startPositionSec))
@@ -131,23 +344,73 @@ func buildMPVArgs(title string, subtitleURLs, urls []string, startPositionSec in
 	for _, subURL := range subtitleURLs {
 		args = append(args, "--sub-file="+subURL)
 	}
-	args = append(args, urls...)
+	for i, url := range urls {
+		if i >= len(meta) || meta[i].Tracks == nil {
+			args = append(args, url)
+			continue
+		}
+		args = append(args, "--{")
+		args = append(args, trackArgs(*meta[i].Tracks)...)
+		args = append(args, url, "--}")
+	}
 
 	return args
 }
 
-func observePlaybackPosition(ipcPath string, position *atomic.Int64) {
+func trackArgs(choice TrackChoice) []string {
+	var user Args
+	if u := userArgs.Load(); u != nil {
+		user = *u
+	}
+	var args []string
+	if choice.AudioLang != "" {
+		args = append(args, "--alang="+strings.Join(append([]string{choice.AudioLang}, user.AudioLangs...), ","))
+	}
+	switch {
+	case choice.SubtitlesOff:
+		args = append(args, "--sid=no")
+	case choice.SubtitleLang != "":
+		args = append(args, "--slang="+strings.Join(append([]string{choice.SubtitleLang}, user.SubtitleLangs...), ","))
+	}
+	return args
+}
+
+const nextUpLeadSec = 30
+
+func observePlayback(ipcPath string, ctrl *Controller, meta []Metadata, onSwitch SwitchFunc) {
 	conn, err := dialIPC(ipcPath)
 	if err != nil {
 		return
 	}
 	defer conn.Close()
 
-	if err := json.NewEncoder(conn).Encode(map[string]any{
-		"command": []any{"observe_property", 1, "time-pos"},
-	}); err != nil {
+	ctrl.mu.Lock()
+	ctrl.enc = json.NewEncoder(conn)
+	ctrl.mu.Unlock()
+	send := ctrl.send
+	if err := send("observe_property", 1, "time-pos"); err != nil {
 		return
 	}
+	_ = send("observe_property", 3, "duration")
+	_ = send("observe_property", 4, "pause")
+	if len(meta) > 0 || onSwitch != nil {
+		_ = send("observe_property", 2, "playlist-pos")
+	}
+	_ = send("observe_property", 5, "current-tracks/audio/lang")
+	_ = send("observe_property", 6, "current-tracks/sub/lang")
+	_ = send("observe_property", 7, "sid")
+
+	index := 0
+	var duration float64
+	nextShown := false
+	started := false
+	var tracks TrackChoice
+	current := func() (Metadata, bool) {
+		if index < 0 || index >= len(meta) {
+			return Metadata{}, false
+		}
+		return meta[index], true
+	}
 
 	scanner := bufio.NewScanner(conn)
 	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
@@ -156,14 +419,105 @@ func observePlaybackPosition(ipcPath string, position *atomic.Int64) {
 		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
 			continue
 		}
-		if event.Event != "property-change" || event.Name != "time-pos" {
-			continue
+
+		switch {
+		case event.Event == "file-loaded":
+			started = false
+			if m, ok := current(); ok && len(m.Chapters) > 0 {
+				_ = send("set_property", "chapter-list", chapterList(m.Chapters))
+			}
+
+		case event.Event == "playback-restart":
+			started = true
+
+		case event.Event == "end-file":
+			started = false
+
+		case event.Event == "property-change" && (event.Name == "current-tracks/audio/lang" || event.Name == "current-tracks/sub/lang" || event.Name == "sid"):
+			before := tracks
+			switch event.Name {
+			case "current-tracks/audio/lang":
+				tracks.AudioLang, _ = event.Data.(string)
+			case "current-tracks/sub/lang":
+				tracks.SubtitleLang, _ = event.Data.(string)
+			case "sid":
+				tracks.SubtitlesOff = event.Data == false || event.Data == "no"
+			}
+			if m, ok := current(); ok && started && m.TrackKey != "" && tracks != before {
+				if fn := trackListener.Load(); fn != nil {
+					(*fn)(m.TrackKey, tracks)
+				}
+			}
+
+		case event.Event == "property-change" && event.Name == "playlist-pos":
+			pos, ok := event.Data.(float64)
+			if !ok {
+				continue
+			}
+			index = int(pos)
+			nextShown = false
+			if prev := ctrl.Index(); index >= 0 && index != prev {
+				ctrl.index.Store(int64(index))
+				if onSwitch != nil {
+					onSwitch(prev, index, ctrl.position.Swap(0))
+				}
+			}
+			if m, ok := current(); ok {
+				if m.Title != "" {
+					ctrl.title.Store(&m.Title)
+					_ = send("set_property", "force-media-title", m.Title)
+				}
+				if len(m.Chapters) > 0 {
+					_ = send("set_property", "chapter-list", chapterList(m.Chapters))
+				}
+			}
+
+		case event.Event == "property-change" && event.Name == "duration":
+			duration, _ = event.Data.(float64)
+			ctrl.duration.Store(int64(duration))
+
+		case event.Event == "property-change" && event.Name == "pause":
+			paused, _ := event.Data.(bool)
+			ctrl.paused.Store(paused)
+
+		case event.Event == "property-change" && event.Name == "time-pos":
+			sec, ok := event.Data.(float64)
+			if !ok || sec < 0 {
+				continue
+			}
+			ctrl.position.Store(int64(sec))
+
+			m, ok := current()
+			if ok && m.Next != "" && !nextShown && duration > nextUpLeadSec && sec >= duration-nextUpLeadSec {
+				nextShown = true
+				_ = send("show-text", "Up next: "+m.Next, 5000)
+			}
 		}
-		sec, ok := event.Data.(float64)
-		if !ok || sec < 0 {
-			continue
+	}
+}
+
+func chapterList(chapters []Chapter) []map[string]any {
+	list := make([]map[string]any, 0, len(chapters))
+	for _, ch := range chapters {
+		list = append(list, map[string]any{
+			"title": ch.Title,
+			"time":  float64(ch.StartSec),
+		})
+	}
+	return list
+}
+
+func reportProgress(position *atomic.Int64, onProgress func(int64), done <-chan struct{}) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			onProgress(position.Load())
 		}
-		position.Store(int64(sec))
 	}
 }
 
@@ -0,0 +1,251 @@
+package script
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	starjson "go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"ember/internal/logging"
+	"ember/internal/service"
+	"ember/internal/storage"
+)
+
+const maxSteps = 50_000_000
+
+type Action struct {
+	Name  string
+	File  string
+	Types []string
+	run   starlark.Callable
+}
+
+func (a Action) Applies(item service.MediaItem) bool {
+	return len(a.Types) == 0 || slices.Contains(a.Types, item.Type)
+}
+
+type Engine struct {
+	svc     *service.MediaService
+	actions []Action
+}
+
+func Dir() string {
+	return filepath.Join(storage.Dir(), "scripts")
+}
+
+func ExportDir() string {
+	return filepath.Join(storage.Dir(), "exports")
+}
+
+func Load(svc *service.MediaService, dir string) (*Engine, error) {
+	e := &Engine{svc: svc}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.star"))
+	if err != nil {
+		return e, fmt.Errorf("failed to list scripts: %w", err)
+	}
+	sort.Strings(paths)
+
+	var errs []error
+	for _, path := range paths {
+		if err := e.loadFile(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return e, errors.Join(errs...)
+}
+
+func (e *Engine) loadFile(path string) error {
+	file := filepath.Base(path)
+	thread := e.thread(file)
+
+	var actions []Action
+	register := starlark.NewBuiltin("action", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		var run starlark.Callable
+		var types *starlark.List
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "run", &run, "types?", &types); err != nil {
+			return nil, err
+		}
+		action := Action{Name: name, File: file, run: run}
+		if types != nil {
+			for i := range types.Len() {
+				t, ok := starlark.AsString(types.Index(i))
+				if !ok {
+					return nil, fmt.Errorf("action %q: types must be strings", name)
+				}
+				action.Types = append(action.Types, t)
+			}
+		}
+		actions = append(actions, action)
+		return starlark.None, nil
+	})
+
+	predeclared := starlark.StringDict{
+		"action": register,
+		"ember":  e.module(),
+		"json":   starjson.Module,
+	}
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, path, nil, predeclared)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", file, err)
+	}
+	globals.Freeze()
+	e.actions = append(e.actions, actions...)
+	return nil
+}
+
+func (e *Engine) Actions(item service.MediaItem) []Action {
+	var actions []Action
+	for _, a := range e.actions {
+		if a.Applies(item) {
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
+func (e *Engine) Run(action Action, item service.MediaItem) (string, error) {
+	thread := e.thread(action.File)
+	arg, err := toValue(thread, item)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := starlark.Call(thread, action.run, starlark.Tuple{arg}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s: %w", action.Name, err)
+	}
+	if s, ok := starlark.AsString(result); ok {
+		return s, nil
+	}
+	if result == starlark.None {
+		return "", nil
+	}
+	return result.String(), nil
+}
+
+func (e *Engine) thread(file string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: file,
+		Print: func(_ *starlark.Thread, msg string) {
+			logging.UI("Script output", "script", file, "msg", msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+	return thread
+}
+
+func (e *Engine) module() *starlarkstruct.Module {
+	return &starlarkstruct.Module{
+		Name: "ember",
+		Members: starlark.StringDict{
+			"item":     starlark.NewBuiltin("item", e.item),
+			"items":    starlark.NewBuiltin("items", e.items),
+			"seasons":  starlark.NewBuiltin("seasons", e.seasons),
+			"episodes": starlark.NewBuiltin("episodes", e.episodes),
+			"search":   starlark.NewBuiltin("search", e.search),
+			"write":    starlark.NewBuiltin("write", e.write),
+		},
+	}
+}
+
+func (e *Engine) item(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "id", &id); err != nil {
+		return nil, err
+	}
+	item, err := e.svc.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+	return toValue(thread, item)
+}
+
+func (e *Engine) items(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var parentID string
+	page, pageSize := 0, 50
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "parent_id", &parentID, "page?", &page, "page_size?", &pageSize); err != nil {
+		return nil, err
+	}
+	list, err := e.svc.GetItems(parentID, page, pageSize, service.ItemFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return toValue(thread, list.Items)
+}
+
+func (e *Engine) seasons(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seriesID string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "series_id", &seriesID); err != nil {
+		return nil, err
+	}
+	list, err := e.svc.GetSeasons(seriesID)
+	if err != nil {
+		return nil, err
+	}
+	return toValue(thread, list.Items)
+}
+
+func (e *Engine) episodes(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seriesID, seasonID string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "series_id", &seriesID, "season_id", &seasonID); err != nil {
+		return nil, err
+	}
+	list, err := e.svc.GetEpisodes(seriesID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	return toValue(thread, list.Items)
+}
+
+func (e *Engine) search(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var query string
+	limit := 20
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "query", &query, "limit?", &limit); err != nil {
+		return nil, err
+	}
+	list, err := e.svc.Search(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toValue(thread, list.Items)
+}
+
+func (e *Engine) write(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name, text string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "text", &text); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid file name %q", name)
+	}
+
+	dir := ExportDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export dir: %w", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return starlark.String(path), nil
+}
+
+func toValue(thread *starlark.Thread, v any) (starlark.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Call(thread, starjson.Module.Members["decode"], starlark.Tuple{starlark.String(data)}, nil)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ember/internal/storage"
+)
+
+type AccessDeniedError struct {
+	Windows []storage.AccessWindow
+	// Invalid holds the parse errors of windows that could not be read
+	// and therefore never allow playback.
+	Invalid []error
+}
+
+func (e *AccessDeniedError) Error() string {
+	msg := "playback not allowed right now (allowed: " + FormatAccessWindows(e.Windows) + ")"
+	if len(e.Invalid) > 0 {
+		msg += ": " + errors.Join(e.Invalid...).Error()
+	}
+	return msg
+}
+
+func (s *MediaService) AccessWindows() []storage.AccessWindow {
+	srv := s.store.GetActiveServer()
+	if srv == nil {
+		return nil
+	}
+	return srv.AccessWindows
+}
+
+func (s *MediaService) checkAccess(now time.Time) error {
+	windows := s.AccessWindows()
+	if len(windows) == 0 {
+		return nil
+	}
+
+	var invalid []error
+	for _, w := range windows {
+		allowed, err := windowAllows(w, now)
+		if err != nil {
+			invalid = append(invalid, err)
+			continue
+		}
+		if allowed {
+			return nil
+		}
+	}
+	return &AccessDeniedError{Windows: windows, Invalid: invalid}
+}
+
+// windowAllows reports whether now falls inside w. A window whose start
+// equals its end covers the whole of each allowed day.
+func windowAllows(w storage.AccessWindow, now time.Time) (bool, error) {
+	start, end, err := parseWindow(w)
+	if err != nil {
+		return false, err
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+	day := now.Weekday()
+	if start == end {
+		return dayAllowed(w.Days, day), nil
+	}
+	if start < end {
+		return dayAllowed(w.Days, day) && minute >= start && minute < end, nil
+	}
+
+	// Window spans midnight, e.g. 22:00-02:00: the early-morning part
+	// belongs to the previous day's window.
+	if minute >= start {
+		return dayAllowed(w.Days, day), nil
+	}
+	return minute < end && dayAllowed(w.Days, (day+6)%7), nil
+}
+
+func parseWindow(w storage.AccessWindow) (int, int, error) {
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("access window %s–%s: start: %w", w.Start, w.End, err)
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return 0, 0, fmt.Errorf("access window %s–%s: end: %w", w.Start, w.End, err)
+	}
+	return start, end, nil
+}
+
+func dayAllowed(days []string, day time.Weekday) bool {
+	if len(days) == 0 {
+		return true
+	}
+	name := strings.ToLower(day.String()[:3])
+	for _, d := range days {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if len(d) >= 3 && d[:3] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", value, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func FormatAccessWindows(windows []storage.AccessWindow) string {
+	if len(windows) == 0 {
+		return "Any time"
+	}
+
+	parts := make([]string, len(windows))
+	for i, w := range windows {
+		parts[i] = w.Start + "–" + w.End
+		if len(w.Days) > 0 {
+			parts[i] += " " + strings.Join(w.Days, ",")
+		}
+		if _, _, err := parseWindow(w); err != nil {
+			parts[i] += " (invalid, never allows)"
+		}
+	}
+	return strings.Join(parts, "; ")
+}
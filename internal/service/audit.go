@@ -0,0 +1,86 @@
+package service
+
+import (
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"ember/internal/logging"
+	"ember/internal/storage"
+)
+
+const (
+	AuditPlayback     = "playback.start"
+	AuditServerAdd    = "server.add"
+	AuditServerUpdate = "server.update"
+	AuditServerDelete = "server.delete"
+	AuditServerSwitch = "server.switch"
+	AuditQueueRemove  = "queue.remove"
+	AuditQueueClear   = "queue.clear"
+
+	// auditViewLimit is how many recent entries the audit view searches.
+	auditViewLimit = 2000
+)
+
+func auditUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+func (s *MediaService) audit(action, subject string) {
+	entry := storage.AuditEntry{
+		Time:    time.Now().Format(time.RFC3339),
+		User:    auditUser(),
+		Action:  action,
+		Subject: subject,
+	}
+	if srv := s.store.GetActiveServer(); srv != nil {
+		entry.Server = srv.Name
+	}
+	if err := s.store.AppendAudit(entry); err != nil {
+		logging.Storage("Failed to write audit entry", "action", action, "error", err)
+	}
+}
+
+func (s *MediaService) auditItemName(itemID string) string {
+	if meta, ok := s.store.GetItemMeta(itemID); ok {
+		if meta.SeriesName != "" {
+			return meta.SeriesName + " - " + meta.Name
+		}
+		return meta.Name
+	}
+	return itemID
+}
+
+func (s *MediaService) AuditLog(query string) ([]storage.AuditEntry, error) {
+	entries, err := s.store.ReadAudit(auditViewLimit)
+	if err != nil {
+		return nil, err
+	}
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return entries, nil
+	}
+
+	matched := entries[:0]
+	for _, entry := range entries {
+		text := strings.ToLower(strings.Join([]string{entry.Time, entry.User, entry.Server, entry.Action, entry.Subject}, " "))
+		all := true
+		for _, term := range terms {
+			if !strings.Contains(text, term) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, entry)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ember/internal/api"
+	"ember/internal/logging"
+	"ember/internal/storage"
+)
+
+const (
+	playMethodLocal     = "DirectPlay"
+	maxDownloadAttempts = 3
+
+	DefaultDownloadTemplate = "{series}/Season {season}/{series} - S{season}E{episode} - {title}"
+)
+
+func (s *MediaService) DownloadDir() string {
+	if dir := s.store.GetSettings().DownloadDir; dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Downloads", "ember")
+}
+
+func (s *MediaService) SetDownloadDir(dir string) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.DownloadDir = dir
+	})
+}
+
+func (s *MediaService) DownloadTemplate() string {
+	if tmpl := s.store.GetSettings().DownloadTemplate; tmpl != "" {
+		return tmpl
+	}
+	return DefaultDownloadTemplate
+}
+
+func (s *MediaService) SetDownloadTemplate(tmpl string) error {
+	if tmpl != "" && !strings.Contains(tmpl, "{episode}") {
+		return fmt.Errorf("download template must contain {episode}")
+	}
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.DownloadTemplate = tmpl
+	})
+	return nil
+}
+
+func (s *MediaService) EpisodesToDownload(item MediaItem) ([]MediaItem, error) {
+	if item.Type != "Series" && item.Type != "Season" {
+		return nil, fmt.Errorf("only seasons and series can be downloaded in bulk")
+	}
+	episodes, err := s.episodesOf(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []MediaItem
+	for _, ep := range episodes {
+		if len(ep.MediaSources) == 0 {
+			continue
+		}
+		if _, ok := s.LocalPath(ep.ID); ok {
+			continue
+		}
+		pending = append(pending, s.convertItem(ep))
+	}
+	return pending, nil
+}
+
+func (s *MediaService) Download(ctx context.Context, itemID string, progress api.DownloadProgress) (*storage.Download, error) {
+	item, err := s.client.GetItem(itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	if len(item.MediaSources) == 0 {
+		return nil, fmt.Errorf("no media source available")
+	}
+
+	source, _ := s.PreferredSource(s.convertItem(*item))
+	container := source.Container
+	if container == "" {
+		container = "mkv"
+	}
+
+	dir := s.DownloadDir()
+
+	d := storage.Download{
+		ItemID:       item.ID,
+		Name:         item.Name,
+		Type:         item.Type,
+		SeriesName:   item.SeriesName,
+		Path:         filepath.Join(dir, downloadFileName(*item, s.DownloadTemplate())+"."+container),
+		ExpectedSize: source.Size,
+	}
+	if existing, ok := s.store.GetDownload(item.ID); ok && !existing.Complete && existing.Path != "" {
+		d.Path = existing.Path
+	}
+	if err := os.MkdirAll(filepath.Dir(d.Path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create download dir: %w", err)
+	}
+	s.store.SetDownload(d)
+
+	var reportedTotal int64
+	track := func(written, total int64) {
+		reportedTotal = total
+		if progress != nil {
+			progress(written, total)
+		}
+	}
+
+	var verifyErr error
+	for attempt := 0; attempt < maxDownloadAttempts; attempt++ {
+		if err := s.client.Download(ctx, item.ID, source.ID, d.Path, track); err != nil {
+			return nil, err
+		}
+		if d.ExpectedSize == 0 {
+			d.ExpectedSize = reportedTotal
+		}
+		if verifyErr = verifyDownloadFile(d); verifyErr == nil {
+			break
+		}
+		logging.Storage("Download failed verification, retrying", "item", item.ID, "attempt", attempt+1, "error", verifyErr)
+		if err := prepareDownloadRetry(d); err != nil {
+			return nil, fmt.Errorf("failed to retry download: %w", err)
+		}
+	}
+	if verifyErr != nil {
+		return nil, fmt.Errorf("download failed verification: %w", verifyErr)
+	}
+
+	if info, err := os.Stat(d.Path); err == nil {
+		d.Size = info.Size()
+	}
+	d.Complete = true
+	d.Verified = d.ExpectedSize > 0
+	d.DownloadedAt = time.Now().Format(time.RFC3339)
+	s.store.SetDownload(d)
+	s.cacheItem(s.convertItem(*item))
+	return &d, nil
+}
+
+func (s *MediaService) LocalPath(itemID string) (string, bool) {
+	d, ok := s.store.GetDownload(itemID)
+	if !ok || !d.Complete {
+		return "", false
+	}
+	if _, err := os.Stat(d.Path); err != nil {
+		return "", false
+	}
+	return d.Path, true
+}
+
+func (s *MediaService) GetDownloads() []storage.Download {
+	return s.store.GetDownloads()
+}
+
+func (s *MediaService) VerifyDownloads() (verified, total int) {
+	for _, d := range s.store.GetDownloads() {
+		if !d.Complete {
+			continue
+		}
+		total++
+		if d.ExpectedSize == 0 {
+			if item, err := s.client.GetItem(d.ItemID); err == nil && len(item.MediaSources) > 0 {
+				d.ExpectedSize = item.MediaSources[0].Size
+			}
+		}
+		err := verifyDownloadFile(d)
+		d.Verified = err == nil && d.ExpectedSize > 0
+		if err != nil {
+			logging.Storage("Download failed verification", "item", d.ItemID, "error", err)
+		}
+		if d.Verified {
+			verified++
+		}
+		s.store.SetDownload(d)
+	}
+	return verified, total
+}
+
+func verifyDownloadFile(d storage.Download) error {
+	info, err := os.Stat(d.Path)
+	if err != nil {
+		return err
+	}
+	if d.ExpectedSize > 0 && info.Size() != d.ExpectedSize {
+		return fmt.Errorf("size mismatch: have %d bytes, expected %d", info.Size(), d.ExpectedSize)
+	}
+	return nil
+}
+
+func prepareDownloadRetry(d storage.Download) error {
+	info, err := os.Stat(d.Path)
+	if err != nil {
+		return err
+	}
+	if info.Size() < d.ExpectedSize {
+		return os.Rename(d.Path, d.Path+".part")
+	}
+	return os.Remove(d.Path)
+}
+
+func downloadFileName(item api.MediaItem, template string) string {
+	if item.Type == "Episode" && item.SeriesName != "" {
+		episode := fmt.Sprintf("%02d", item.IndexNumber)
+		if item.IndexNumberEnd > item.IndexNumber {
+			episode += fmt.Sprintf("-E%02d", item.IndexNumberEnd)
+		}
+		replacer := strings.NewReplacer(
+			"{series}", sanitizeFileName(item.SeriesName),
+			"{season}", fmt.Sprintf("%02d", item.ParentIndexNumber),
+			"{season_name}", sanitizeFileName(item.SeasonName),
+			"{episode}", episode,
+			"{title}", sanitizeFileName(item.Name),
+			"{year}", fmt.Sprintf("%d", item.Year),
+		)
+		var parts []string
+		for _, part := range strings.Split(replacer.Replace(template), "/") {
+			part = strings.TrimSpace(part)
+			if part == "" || part == "." || part == ".." {
+				continue
+			}
+			parts = append(parts, sanitizeFileName(part))
+		}
+		if len(parts) > 0 {
+			return filepath.Join(parts...)
+		}
+	}
+
+	name := item.Name
+	if item.Year > 0 {
+		name = fmt.Sprintf("%s (%d)", item.Name, item.Year)
+	}
+	return sanitizeFileName(name)
+}
+
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, name)
+	return strings.TrimSpace(name)
+}
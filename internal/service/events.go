@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventPlaybackStarted EventType = "playback.started"
+	EventPlaybackStopped EventType = "playback.stopped"
+	EventFavoriteChanged EventType = "favorite.changed"
+	EventPlayedChanged   EventType = "played.changed"
+	EventRatingChanged   EventType = "rating.changed"
+	EventServerSwitched  EventType = "server.switched"
+)
+
+type Event struct {
+	Type        EventType `json:"type"`
+	ItemID      string    `json:"itemId,omitempty"`
+	PositionSec int64     `json:"positionSec,omitempty"`
+	Favorite    bool      `json:"favorite,omitempty"`
+	Played      bool      `json:"played,omitempty"`
+	Likes       *bool     `json:"likes,omitempty"`
+	Server      string    `json:"server,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+type EventBus struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]chan Event
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[int]chan Event)}
+}
+
+func (b *EventBus) Subscribe(buffer int) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+	ch := make(chan Event, max(1, buffer))
+	b.subs[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs, id)
+			close(ch)
+		})
+	}
+}
+
+func (b *EventBus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
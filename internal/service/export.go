@@ -0,0 +1,104 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ember/internal/api"
+	"ember/internal/logging"
+)
+
+// ExportResult describes a written playlist. Skipped counts the entries
+// left out because they could not be fetched or had nothing to stream.
+type ExportResult struct {
+	Path    string
+	Written int
+	Skipped int
+}
+
+func (s *MediaService) ExportM3U(item MediaItem) (ExportResult, error) {
+	var items []api.MediaItem
+	switch item.Type {
+	case "Season":
+		seriesID := item.SeriesID
+		if seriesID == "" {
+			seriesID = item.ParentID
+		}
+		episodes, err := s.client.GetEpisodes(seriesID, item.ID, false)
+		if err != nil {
+			return ExportResult{}, fmt.Errorf("failed to get episodes: %w", err)
+		}
+		items = episodes
+	case "Playlist":
+		entries, err := s.client.GetPlaylistItems(item.ID)
+		if err != nil {
+			return ExportResult{}, fmt.Errorf("failed to get playlist items: %w", err)
+		}
+		items = entries
+	default:
+		return ExportResult{}, fmt.Errorf("only seasons and playlists can be exported")
+	}
+
+	title := item.Name
+	if item.Type == "Season" && item.SeriesName != "" {
+		title = item.SeriesName + " - " + item.Name
+	}
+	return s.writeM3U(title, items)
+}
+
+func (s *MediaService) ExportQueueM3U() (ExportResult, error) {
+	queue := s.store.GetQueue()
+	if len(queue) == 0 {
+		return ExportResult{}, fmt.Errorf("queue is empty")
+	}
+	items := make([]api.MediaItem, 0, len(queue))
+	skipped := 0
+	for _, e := range queue {
+		full, err := s.client.GetItem(e.ItemID)
+		if err != nil {
+			logging.Storage("Skipping queue entry in export", "item", e.ItemID, "error", err)
+			skipped++
+			continue
+		}
+		items = append(items, *full)
+	}
+	result, err := s.writeM3U("Queue", items)
+	result.Skipped += skipped
+	return result, err
+}
+
+func (s *MediaService) writeM3U(title string, items []api.MediaItem) (ExportResult, error) {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#PLAYLIST:" + title + "\n")
+	written, skipped := 0, 0
+	for _, item := range items {
+		location, ok := s.LocalPath(item.ID)
+		if !ok {
+			if len(item.MediaSources) == 0 {
+				logging.Storage("Skipping item without media sources in export", "item", item.ID)
+				skipped++
+				continue
+			}
+			ms := item.MediaSources[0]
+			location = s.client.StreamURL(item.ID, ms.ID, ms.Container)
+		}
+		fmt.Fprintf(&b, "#EXTINF:%d,%s\n%s\n", item.RunTimeTicks/10_000_000, MediaTitle(s.convertItem(item)), location)
+		written++
+	}
+	if written == 0 {
+		return ExportResult{Skipped: skipped}, fmt.Errorf("nothing playable to export")
+	}
+
+	dir := filepath.Join(s.DownloadDir(), "Playlists")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ExportResult{}, fmt.Errorf("failed to create playlist directory: %w", err)
+	}
+	path := filepath.Join(dir, sanitizeFileName(title)+".m3u8")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return ExportResult{}, fmt.Errorf("failed to write playlist: %w", err)
+	}
+	return ExportResult{Path: path, Written: written, Skipped: skipped}, nil
+}
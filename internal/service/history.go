@@ -0,0 +1,131 @@
+package service
+
+import (
+	"sync/atomic"
+	"time"
+
+	"ember/internal/logging"
+	"ember/internal/player"
+	"ember/internal/storage"
+)
+
+type playbackSession struct {
+	itemID    string
+	startedAt time.Time
+	startSec  int64
+	genres    atomic.Pointer[[]string]
+}
+
+func (s *MediaService) beginPlaybackSession(itemID, sessionID string, positionSec int64) {
+	session := &playbackSession{
+		itemID:    itemID,
+		startedAt: time.Now(),
+		startSec:  positionSec,
+	}
+	s.sessions.Store(sessionID, session)
+	if s.Analytics() {
+		s.captureGenres(session)
+	}
+}
+
+func (s *MediaService) openSessions() int {
+	n := 0
+	s.sessions.Range(func(any, any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func (s *MediaService) Shutdown(timeout time.Duration) {
+	player.Shutdown(timeout)
+	deadline := time.Now().Add(timeout)
+	for s.openSessions() > 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if n := s.openSessions(); n > 0 {
+		logging.Player("Exiting with unreported playback", "sessions", n)
+	}
+}
+
+func (s *MediaService) recordPlayback(itemID, sessionID string, positionSec, durationSec int64) {
+	session := &playbackSession{itemID: itemID, startedAt: time.Now()}
+	if v, ok := s.sessions.LoadAndDelete(sessionID); ok {
+		session = v.(*playbackSession)
+	}
+	if session.itemID != itemID {
+		session = &playbackSession{itemID: itemID, startedAt: time.Now(), startSec: positionSec}
+	}
+
+	now := time.Now()
+	watched := int64(now.Sub(session.startedAt).Seconds())
+	if durationSec > 0 {
+		watched = min(watched, durationSec)
+	}
+
+	record := storage.PlaybackRecord{
+		ItemID:      itemID,
+		StartedAt:   session.startedAt.Format(time.RFC3339),
+		EndedAt:     now.Format(time.RFC3339),
+		StartSec:    session.startSec,
+		EndSec:      positionSec,
+		WatchedSec:  watched,
+		DurationSec: durationSec,
+	}
+	if meta, ok := s.store.GetItemMeta(itemID); ok {
+		record.Name = meta.Name
+		record.Type = meta.Type
+		record.SeriesName = meta.SeriesName
+		if genres := session.genres.Load(); genres != nil && s.Analytics() {
+			record.Genres = *genres
+		}
+	}
+	if srv := s.store.GetActiveServer(); srv != nil {
+		record.Server = srv.Name
+	}
+	s.store.AddPlaybackRecord(record)
+}
+
+func (s *MediaService) GetPlaybackLog(page, pageSize int) (*MediaList, error) {
+	if page < 0 {
+		page = 0
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	records := s.store.GetPlaybackRecords()
+	start := min(page*pageSize, len(records))
+	end := min(start+pageSize, len(records))
+
+	items := make([]MediaItem, 0, end-start)
+	for _, r := range records[start:end] {
+		item, ok := s.cachedItem(r.ItemID)
+		if !ok {
+			item = MediaItem{
+				ID:           r.ItemID,
+				Name:         r.Name,
+				Type:         r.Type,
+				SeriesName:   r.SeriesName,
+				RunTimeTicks: r.DurationSec * 10_000_000,
+				MediaSources: []MediaSource{{}},
+				Playable:     true,
+			}
+		}
+		if item.UserData == nil {
+			item.UserData = &UserData{}
+		}
+		item.UserData.LastPlayedDate = r.EndedAt
+		item.UserData.PlaybackPositionTicks = r.EndSec * 10_000_000
+		item.UserData.Played = r.Completed()
+		items = append(items, item)
+	}
+
+	return &MediaList{
+		Items:    items,
+		Total:    len(records),
+		Page:     page,
+		PageSize: pageSize,
+		HasMore:  end < len(records),
+	}, nil
+}
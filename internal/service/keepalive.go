@@ -0,0 +1,31 @@
+package service
+
+import (
+	"fmt"
+
+	"ember/internal/api"
+	"ember/internal/logging"
+)
+
+var capabilities = api.Capabilities{
+	PlayableMediaTypes: []string{"Audio", "Video"},
+	SupportedCommands:  []string{},
+}
+
+func (s *MediaService) KeepAlive() error {
+	if s.store.GetActiveServer() == nil || s.client.Token == "" {
+		return nil
+	}
+	if err := s.client.ReportCapabilities(capabilities); err != nil {
+		return fmt.Errorf("failed to report capabilities: %w", err)
+	}
+
+	s.sessions.Range(func(key, _ any) bool {
+		sessionID := key.(string)
+		if err := s.client.PingPlayback(sessionID); err != nil {
+			logging.Player("Playback ping failed", "session", sessionID, "error", err)
+		}
+		return true
+	})
+	return nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"ember/internal/storage"
+)
+
+const minKioskPINLength = 4
+
+var ErrKioskPIN = errors.New("wrong PIN")
+
+type KioskLock struct {
+	LibraryID   string
+	LibraryName string
+}
+
+func hashKioskPIN(pin string) string {
+	sum := sha256.Sum256([]byte("ember-kiosk:" + pin))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *MediaService) Kiosk() (KioskLock, bool) {
+	settings := s.store.GetSettings()
+	if settings.KioskLibraryID == "" {
+		return KioskLock{}, false
+	}
+	return KioskLock{LibraryID: settings.KioskLibraryID, LibraryName: settings.KioskLibraryName}, true
+}
+
+func (s *MediaService) HasKioskPIN() bool {
+	return s.store.GetSettings().KioskPINHash != ""
+}
+
+func (s *MediaService) SetKioskPIN(pin string) error {
+	if len(pin) < minKioskPINLength {
+		return fmt.Errorf("PIN must be at least %d characters", minKioskPINLength)
+	}
+	hash := hashKioskPIN(pin)
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.KioskPINHash = hash
+	})
+	return nil
+}
+
+func (s *MediaService) CheckKioskPIN(pin string) bool {
+	stored := s.store.GetSettings().KioskPINHash
+	if stored == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(hashKioskPIN(pin))) == 1
+}
+
+func (s *MediaService) EnterKiosk(lock KioskLock) error {
+	if !s.HasKioskPIN() {
+		return fmt.Errorf("set a kiosk PIN first")
+	}
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.KioskLibraryID = lock.LibraryID
+		settings.KioskLibraryName = lock.LibraryName
+	})
+	return nil
+}
+
+func (s *MediaService) ExitKiosk(pin string) error {
+	if !s.CheckKioskPIN(pin) {
+		return ErrKioskPIN
+	}
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.KioskLibraryID = ""
+		settings.KioskLibraryName = ""
+	})
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"slices"
+	"strings"
+
+	"ember/internal/player"
+	"ember/internal/storage"
+)
+
+var DefaultSubtitleLangs = []string{"chi", "zho", "zh", "chs", "cht", "cn", "chinese"}
+
+func playerArgs(settings storage.Settings) player.Args {
+	return player.Args{
+		Profile:        settings.MPVProfile,
+		Extra:          settings.MPVArgs,
+		SubtitleLangs:  subtitleLangs(settings),
+		AudioLangs:     normalizeLangs(settings.AudioLangs),
+		ForcedSubsOnly: settings.ForcedSubsOnly,
+	}
+}
+
+func subtitleLangs(settings storage.Settings) []string {
+	if langs := normalizeLangs(settings.SubtitleLangs); len(langs) > 0 {
+		return langs
+	}
+	return DefaultSubtitleLangs
+}
+
+func (s *MediaService) SubtitleLangs() []string {
+	return subtitleLangs(s.store.GetSettings())
+}
+
+func (s *MediaService) AudioLangs() []string {
+	return normalizeLangs(s.store.GetSettings().AudioLangs)
+}
+
+func (s *MediaService) ForcedSubsOnly() bool {
+	return s.store.GetSettings().ForcedSubsOnly
+}
+
+func (s *MediaService) SetForcedSubsOnly(enabled bool) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.ForcedSubsOnly = enabled
+	})
+	player.SetArgs(s.MPVArgs())
+}
+
+func normalizeLangs(langs []string) []string {
+	var result []string
+	for _, lang := range langs {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang != "" && !slices.Contains(result, lang) {
+			result = append(result, lang)
+		}
+	}
+	return result
+}
+
+func langRank(lang string, prefs []string) int {
+	lang = strings.ToLower(lang)
+	for i, pref := range prefs {
+		if lang == pref {
+			return i
+		}
+	}
+	return len(prefs)
+}
+
+func sortByLanguage[T any](items []T, lang func(T) string, prefs []string) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		return langRank(lang(a), prefs) - langRank(lang(b), prefs)
+	})
+}
+
+func preferredAudioIndex(tracks []AudioTrack, prefs []string) int {
+	best, bestRank := -1, len(prefs)
+	for _, track := range tracks {
+		if rank := langRank(track.Language, prefs); rank < bestRank {
+			best, bestRank = track.Index, rank
+		}
+	}
+	return best
+}
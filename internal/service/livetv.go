@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+func (s *MediaService) GetChannels(page, pageSize int) (*MediaList, error) {
+	if page < 0 {
+		page = 0
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	items, total, err := s.client.GetLiveTvChannels(page*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels: %w", err)
+	}
+
+	return &MediaList{
+		Items:    s.convertItems(items),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		HasMore:  (page+1)*pageSize < total,
+	}, nil
+}
+
+func (s *MediaService) GetGuide(channels []MediaItem, from time.Time, span time.Duration) ([]GuideRow, error) {
+	ids := make([]string, 0, len(channels))
+	for _, ch := range channels {
+		ids = append(ids, ch.ID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	programs, err := s.client.GetLiveTvPrograms(ids, from, from.Add(span))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get programs: %w", err)
+	}
+
+	byChannel := make(map[string][]Program, len(channels))
+	for _, p := range programs {
+		start, err1 := time.Parse(time.RFC3339, p.StartDate)
+		end, err2 := time.Parse(time.RFC3339, p.EndDate)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		byChannel[p.ChannelID] = append(byChannel[p.ChannelID], Program{
+			ID:      p.ID,
+			Name:    p.Name,
+			Episode: p.EpisodeTitle,
+			Start:   start.Local(),
+			End:     end.Local(),
+		})
+	}
+
+	rows := make([]GuideRow, 0, len(channels))
+	for _, ch := range channels {
+		rows = append(rows, GuideRow{Channel: ch, Programs: byChannel[ch.ID]})
+	}
+	return rows, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"ember/internal/api"
+)
+
+var (
+	ErrServerUnreachable = errors.New("server unreachable")
+	ErrNotEmbyServer     = errors.New("no Emby server at this URL")
+	ErrWrongPassword     = errors.New("wrong username or password")
+	ErrLoginSuspended    = errors.New("saved password was rejected; edit the server (e) to update it")
+)
+
+func NormalizeServerURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("URL is required")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid server URL %q (expected http://host:port)", raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("server URL must not have a query or fragment")
+	}
+	return strings.TrimSuffix(strings.TrimRight(u.String(), "/"), "/emby"), nil
+}
+
+func Login(client *api.Client, username, password string) error {
+	if _, err := client.GetPublicInfo(); err != nil {
+		if isUnreachable(err) {
+			return fmt.Errorf("%w: %v", ErrServerUnreachable, err)
+		}
+		return fmt.Errorf("%w: %v", ErrNotEmbyServer, err)
+	}
+	if err := client.Login(username, password); err != nil {
+		if api.IsStatus(err, http.StatusUnauthorized) {
+			return ErrWrongPassword
+		}
+		return fmt.Errorf("login failed: %w", err)
+	}
+	return nil
+}
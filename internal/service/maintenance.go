@@ -0,0 +1,39 @@
+package service
+
+import (
+	"net/http"
+
+	"ember/internal/api"
+)
+
+type Maintenance string
+
+const (
+	MaintenanceNone           Maintenance = ""
+	MaintenanceRestartPending Maintenance = "restart-pending"
+	MaintenanceRestarting     Maintenance = "restarting"
+)
+
+func (s *MediaService) checkMaintenance() (bool, Maintenance) {
+	info, err := s.client.GetSystemInfo()
+	if err == nil {
+		s.restartSeen.Store(info.HasPendingRestart)
+		if info.HasPendingRestart {
+			return true, MaintenanceRestartPending
+		}
+		return true, MaintenanceNone
+	}
+
+	if IsMaintenanceError(err) {
+		s.restartSeen.Store(true)
+		return false, MaintenanceRestarting
+	}
+	if isUnreachable(err) && s.restartSeen.Load() {
+		return false, MaintenanceRestarting
+	}
+	return false, MaintenanceNone
+}
+
+func IsMaintenanceError(err error) bool {
+	return api.IsStatus(err, http.StatusServiceUnavailable)
+}
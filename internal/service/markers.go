@@ -0,0 +1,60 @@
+package service
+
+import "sort"
+
+const (
+	MarkerChapter = "chapter"
+	MarkerIntro   = "intro"
+	MarkerCredits = "credits"
+)
+
+// PlaybackMarker is a chapter boundary (EndSec 0) or a segment such as an
+// intro or the credits, drawn on the playback progress bar.
+type PlaybackMarker struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name,omitempty"`
+	StartSec int64  `json:"startSec"`
+	EndSec   int64  `json:"endSec,omitempty"`
+}
+
+// PlaybackMarkers turns the item's chapters, including Emby's intro and
+// credit chapter markers, into markers. Items loaded from a list carry no
+// chapters, so they are fetched in full first.
+func (s *MediaService) PlaybackMarkers(item MediaItem) []PlaybackMarker {
+	chapters, runtime := item.Chapters, item.RunTimeTicks/10_000_000
+	if len(chapters) == 0 {
+		if full, err := s.client.GetItem(item.ID); err == nil {
+			converted := s.convertItem(*full)
+			chapters, runtime = converted.Chapters, converted.RunTimeTicks/10_000_000
+		}
+	}
+
+	var markers []PlaybackMarker
+	var intro, credits *PlaybackMarker
+	for _, ch := range chapters {
+		switch ch.MarkerType {
+		case "IntroStart":
+			intro = &PlaybackMarker{Kind: MarkerIntro, StartSec: ch.StartSec}
+		case "IntroEnd":
+			if intro == nil {
+				intro = &PlaybackMarker{Kind: MarkerIntro}
+			}
+			intro.EndSec = ch.StartSec
+		case "CreditsStart":
+			credits = &PlaybackMarker{Kind: MarkerCredits, StartSec: ch.StartSec, EndSec: runtime}
+		default:
+			if ch.StartSec > 0 {
+				markers = append(markers, PlaybackMarker{Kind: MarkerChapter, Name: ch.Name, StartSec: ch.StartSec})
+			}
+		}
+	}
+
+	if intro != nil && intro.EndSec > intro.StartSec {
+		markers = append(markers, *intro)
+	}
+	if credits != nil && credits.EndSec > credits.StartSec {
+		markers = append(markers, *credits)
+	}
+	sort.SliceStable(markers, func(i, j int) bool { return markers[i].StartSec < markers[j].StartSec })
+	return markers
+}
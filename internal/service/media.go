@@ -1,24 +1,60 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"ember/internal/api"
+	"ember/internal/logging"
 	"ember/internal/player"
 	"ember/internal/storage"
 )
 
+const (
+	playMethodDirect    = "DirectStream"
+	playMethodTranscode = "Transcode"
+)
+
 type MediaService struct {
 	client *api.Client
 	store  *storage.Store
+	events *EventBus
+
+	offline      atomic.Bool
+	restartSeen  atomic.Bool
+	sessions     sync.Map
+	preferDirect sync.Map
+	liveStreams  sync.Map
+
+	overrideMu sync.Mutex
+	mpvProfile string
+	mpvExtra   []string
 }
 
 func NewMediaService(client *api.Client, store *storage.Store) *MediaService {
-	return &MediaService{
+	settings := store.GetSettings()
+	for _, name := range settings.DisabledLogCategories {
+		logging.SetCategoryEnabled(logging.Category(name), false)
+	}
+	player.SetArgs(playerArgs(settings))
+
+	s := &MediaService{
 		client: client,
 		store:  store,
+		events: NewEventBus(),
 	}
+	player.SetTrackListener(s.rememberTracks)
+	return s
+}
+
+func (s *MediaService) Events() *EventBus {
+	return s.events
 }
 
 func (s *MediaService) SetClient(client *api.Client) {
@@ -29,108 +65,319 @@ func (s *MediaService) Store() *storage.Store {
 	return s.store
 }
 func (s *MediaService) GetResume(limit int) (*MediaList, error) {
-	if limit <= 0 {
-		limit = 20
-	}
+	return s.GetResumeAfter("", limit)
+}
 
-	items, err := s.client.GetResumeItems(limit)
+func (s *MediaService) GetResumeAfter(after string, limit int) (*MediaList, error) {
+	start, err := parseContinuation(after)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get resume items: %w", err)
+		return nil, err
 	}
+	return s.cachedList(fmt.Sprintf("resume:%d:%d", start, limit), func() (*MediaList, error) {
+		if limit <= 0 {
+			limit = 20
+		}
 
-	return &MediaList{
-		Items:    s.convertItems(items),
-		Total:    len(items),
-		Page:     0,
-		PageSize: limit,
-		HasMore:  false,
-	}, nil
-}
+		items, total, err := s.client.GetResumeItems(start, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resume items: %w", err)
+		}
 
-func (s *MediaService) GetFavorites(limit int) (*MediaList, error) {
-	if limit <= 0 {
-		limit = 50
-	}
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			PageSize: limit,
+			HasMore:  start+len(items) < total,
+			Next:     continuation(start, len(items), total),
+		}, nil
+	})
+}
 
-	items, err := s.client.GetFavorites(limit)
+func (s *MediaService) LastResumeItem() (*MediaItem, error) {
+	list, err := s.GetResume(1)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get favorites: %w", err)
+		return nil, err
 	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("nothing to resume")
+	}
+	return &list.Items[0], nil
+}
 
-	return &MediaList{
-		Items:    s.convertItems(items),
-		Total:    len(items),
-		Page:     0,
-		PageSize: limit,
-		HasMore:  false,
-	}, nil
+func (s *MediaService) GetFavorites(limit int) (*MediaList, error) {
+	return s.GetFavoritesAfter("", limit)
 }
 
-func (s *MediaService) GetLibraries() (*MediaList, error) {
-	items, err := s.client.GetLibraries()
+func (s *MediaService) GetFavoritesAfter(after string, limit int) (*MediaList, error) {
+	start, err := parseContinuation(after)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get libraries: %w", err)
+		return nil, err
 	}
+	hide := s.HideWatchedIn(WatchedScopeFavorites)
+	return s.cachedList(fmt.Sprintf("favorites:%d:%d:%t", start, limit, hide), func() (*MediaList, error) {
+		if limit <= 0 {
+			limit = 50
+		}
 
-	return &MediaList{
-		Items:    s.convertItems(items),
-		Total:    len(items),
-		Page:     0,
-		PageSize: len(items),
-		HasMore:  false,
-	}, nil
+		// Watched items are filtered by the server; dropping them from each
+		// page here could leave a page empty with more still to come.
+		items, total, err := s.client.GetFavorites(start, limit, hide)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get favorites: %w", err)
+		}
+		next := continuation(start, len(items), total)
+		hidden := 0
+		if hide && start == 0 {
+			if _, all, err := s.client.GetFavorites(0, 1, false); err == nil {
+				hidden = max(all-total, 0)
+			}
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Hidden:   hidden,
+			PageSize: limit,
+			HasMore:  next != "",
+			Next:     next,
+		}, nil
+	})
 }
 
-func (s *MediaService) GetItems(parentID string, page, pageSize int) (*MediaList, error) {
-	if pageSize <= 0 {
-		pageSize = 20
+func parseContinuation(after string) (int, error) {
+	if after == "" {
+		return 0, nil
 	}
-	if page < 0 {
-		page = 0
+	start, err := strconv.Atoi(after)
+	if err != nil || start < 0 {
+		return 0, fmt.Errorf("invalid continuation token: %q", after)
 	}
+	return start, nil
+}
 
-	items, total, err := s.client.GetItems(parentID, page*pageSize, pageSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get items: %w", err)
+func continuation(start, fetched, total int) string {
+	if fetched == 0 || start+fetched >= total {
+		return ""
 	}
+	return strconv.Itoa(start + fetched)
+}
 
-	return &MediaList{
-		Items:    s.convertItems(items),
-		Total:    total,
-		Page:     page,
-		PageSize: pageSize,
-		HasMore:  (page+1)*pageSize < total,
-	}, nil
+func (s *MediaService) GetLibraries() (*MediaList, error) {
+	return s.cachedList("libraries", func() (*MediaList, error) {
+		items, err := s.client.GetLibraries()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get libraries: %w", err)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    len(items),
+			Page:     0,
+			PageSize: len(items),
+			HasMore:  false,
+		}, nil
+	})
 }
 
-func (s *MediaService) GetSeasons(seriesID string) (*MediaList, error) {
-	items, err := s.client.GetSeasons(seriesID)
+func (s *MediaService) GetItems(parentID string, page, pageSize int, filter ItemFilter) (*MediaList, error) {
+	hide := s.HideWatchedIn(LibraryWatchedScope(parentID))
+	return s.cachedList(fmt.Sprintf("items:%s:%d:%d:%+v:%t", parentID, page, pageSize, filter, hide), func() (*MediaList, error) {
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+		if page < 0 {
+			page = 0
+		}
+
+		countHidden := hide && !filter.Unwatched
+		all := filter
+		if hide {
+			filter.Unwatched = true
+		}
+
+		items, total, err := s.client.GetItems(parentID, page*pageSize, pageSize, filter.query())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get items: %w", err)
+		}
+
+		hidden := 0
+		if countHidden {
+			if _, allTotal, err := s.client.GetItems(parentID, 0, 1, all.query()); err == nil {
+				hidden = max(0, allTotal-total)
+			}
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Hidden:   hidden,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
+func (s *MediaService) GetSimilar(item MediaItem, limit int) ([]MediaItem, error) {
+	itemID := item.ID
+	if (item.Type == "Episode" || item.Type == "Season") && item.SeriesID != "" {
+		itemID = item.SeriesID
+	}
+	list, err := s.cachedList(fmt.Sprintf("similar:%s:%d", itemID, limit), func() (*MediaList, error) {
+		items, err := s.client.GetSimilarItems(itemID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get similar items: %w", err)
+		}
+		return &MediaList{Items: s.convertItems(items), Total: len(items)}, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seasons: %w", err)
+		return nil, err
 	}
+	return list.Items, nil
+}
 
-	return &MediaList{
-		Items:    s.convertItems(items),
-		Total:    len(items),
-		Page:     0,
-		PageSize: len(items),
-		HasMore:  false,
-	}, nil
+func (s *MediaService) GetStudios(page, pageSize int) (*MediaList, error) {
+	return s.cachedList(fmt.Sprintf("studios:%d:%d", page, pageSize), func() (*MediaList, error) {
+		if page < 0 {
+			page = 0
+		}
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+
+		items, total, err := s.client.GetStudios(page*pageSize, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get studios: %w", err)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
+func (s *MediaService) GetStudioItems(studioID string, page, pageSize int) (*MediaList, error) {
+	return s.cachedList(fmt.Sprintf("studio:%s:%d:%d", studioID, page, pageSize), func() (*MediaList, error) {
+		if page < 0 {
+			page = 0
+		}
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+
+		items, total, err := s.client.GetStudioItems(studioID, page*pageSize, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get studio items: %w", err)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
+func (s *MediaService) GetGenres(parentID string, page, pageSize int) (*MediaList, error) {
+	return s.cachedList(fmt.Sprintf("genres:%s:%d:%d", parentID, page, pageSize), func() (*MediaList, error) {
+		if page < 0 {
+			page = 0
+		}
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+
+		items, total, err := s.client.GetGenres(parentID, page*pageSize, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get genres: %w", err)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
+func (s *MediaService) GetGenreItems(parentID, genreID string, page, pageSize int) (*MediaList, error) {
+	return s.cachedList(fmt.Sprintf("genre:%s:%s:%d:%d", parentID, genreID, page, pageSize), func() (*MediaList, error) {
+		if page < 0 {
+			page = 0
+		}
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+
+		items, total, err := s.client.GetGenreItems(parentID, genreID, page*pageSize, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get genre items: %w", err)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
+func (s *MediaService) GetSeasons(seriesID string) (*MediaList, error) {
+	hide := s.HideWatchedIn(SeriesWatchedScope(seriesID))
+	return s.cachedList(fmt.Sprintf("seasons:%s:%t", seriesID, hide), func() (*MediaList, error) {
+		items, err := s.client.GetSeasons(seriesID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seasons: %w", err)
+		}
+
+		hidden := 0
+		if hide {
+			items, hidden = unplayedOnly(items)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    len(items),
+			Hidden:   hidden,
+			Page:     0,
+			PageSize: len(items),
+			HasMore:  false,
+		}, nil
+	})
 }
 
 func (s *MediaService) GetEpisodes(seriesID, seasonID string) (*MediaList, error) {
-	items, err := s.client.GetEpisodes(seriesID, seasonID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get episodes: %w", err)
-	}
+	hide := s.HideWatchedIn(SeriesWatchedScope(seriesID))
+	return s.cachedList(fmt.Sprintf("episodes:%s:%s:%t", seriesID, seasonID, hide), func() (*MediaList, error) {
+		items, err := s.client.GetEpisodes(seriesID, seasonID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get episodes: %w", err)
+		}
 
-	return &MediaList{
-		Items:    s.convertItems(items),
-		Total:    len(items),
-		Page:     0,
-		PageSize: len(items),
-		HasMore:  false,
-	}, nil
+		hidden := 0
+		if hide {
+			items, hidden = unplayedOnly(items)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    len(items),
+			Hidden:   hidden,
+			Page:     0,
+			PageSize: len(items),
+			HasMore:  false,
+		}, nil
+	})
 }
 
 func (s *MediaService) Search(query string, limit int) (*MediaList, error) {
@@ -172,7 +419,7 @@ func (s *MediaService) SearchWithOptions(q SearchQuery) (*MediaList, error) {
 	}
 
 	return &MediaList{
-		Items:    s.convertItems(items),
+		Items:    s.rankByLocalSignals(s.convertItems(items)),
 		Total:    total,
 		Page:     q.Page,
 		PageSize: q.Limit,
@@ -181,25 +428,266 @@ func (s *MediaService) SearchWithOptions(q SearchQuery) (*MediaList, error) {
 }
 
 func (s *MediaService) GetHistory(page, pageSize int) (*MediaList, error) {
-	if page < 0 {
-		page = 0
+	return s.cachedList(fmt.Sprintf("history:%d:%d", page, pageSize), func() (*MediaList, error) {
+		if page < 0 {
+			page = 0
+		}
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+
+		items, total, err := s.client.GetHistory(page*pageSize, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get history: %w", err)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
+const defaultReleaseWindowDays = 90
+
+func (s *MediaService) ReleaseWindowDays() int {
+	days := s.store.GetSettings().ReleaseWindowDays
+	if days <= 0 {
+		return defaultReleaseWindowDays
 	}
-	if pageSize <= 0 {
-		pageSize = 20
+	return days
+}
+
+func (s *MediaService) SetReleaseWindowDays(days int) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.ReleaseWindowDays = days
+	})
+}
+
+const defaultPlayedThreshold = 92
+
+func (s *MediaService) PlayedThreshold() int {
+	percent := s.store.GetSettings().PlayedThreshold
+	if percent == 0 {
+		return defaultPlayedThreshold
 	}
+	return percent
+}
 
-	items, total, err := s.client.GetHistory(page*pageSize, pageSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get history: %w", err)
+func (s *MediaService) SetPlayedThreshold(percent int) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.PlayedThreshold = percent
+	})
+}
+
+const defaultResumeRewindSec = 10
+
+func (s *MediaService) ResumeRewind() int {
+	sec := s.store.GetSettings().ResumeRewindSec
+	if sec == 0 {
+		return defaultResumeRewindSec
 	}
+	return sec
+}
 
-	return &MediaList{
-		Items:    s.convertItems(items),
-		Total:    total,
-		Page:     page,
-		PageSize: pageSize,
-		HasMore:  (page+1)*pageSize < total,
-	}, nil
+func (s *MediaService) SetResumeRewind(sec int) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.ResumeRewindSec = sec
+	})
+}
+
+func (s *MediaService) PlaybackHistory(item MediaItem) PlaybackHistory {
+	var history PlaybackHistory
+	if ud := item.UserData; ud != nil {
+		history.PlayCount = ud.PlayCount
+		history.PositionSec = ud.PlaybackPositionTicks / 10000000
+		if t, err := time.Parse(time.RFC3339, ud.LastPlayedDate); err == nil {
+			history.LastPlayed = t.Local()
+		}
+	}
+
+	detail, ok := s.store.GetMediaDetail(item.ID)
+	if !ok || detail.UpdatedAt == "" {
+		return history
+	}
+	updated, err := time.Parse(time.RFC3339, detail.UpdatedAt)
+	if err != nil || !updated.After(history.LastPlayed) {
+		return history
+	}
+	history.LastPlayed = updated
+	history.PositionSec = detail.PositionSec
+	return history
+}
+
+func (s *MediaService) SetLogCategoryEnabled(category logging.Category, enabled bool) {
+	logging.SetCategoryEnabled(category, enabled)
+
+	var disabled []string
+	for _, c := range logging.Categories {
+		if !logging.CategoryEnabled(c) {
+			disabled = append(disabled, string(c))
+		}
+	}
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.DisabledLogCategories = disabled
+	})
+}
+
+func (s *MediaService) ImageRenderer() string {
+	return s.store.GetSettings().ImageRenderer
+}
+
+func (s *MediaService) SetImageRenderer(name string) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.ImageRenderer = name
+	})
+}
+
+func (s *MediaService) ImageQuality() string {
+	return s.store.GetSettings().ImageQuality
+}
+
+func (s *MediaService) SetImageQuality(quality string) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.ImageQuality = quality
+	})
+}
+
+// WatchedScopeFavorites and the scope functions below name the views
+// whose "hide watched" choice is remembered on its own.
+const WatchedScopeFavorites = "favorites"
+
+func LibraryWatchedScope(parentID string) string {
+	return "library:" + parentID
+}
+
+func SeriesWatchedScope(seriesID string) string {
+	return "series:" + seriesID
+}
+
+func (s *MediaService) HideWatched() bool {
+	return s.store.GetSettings().HideWatched
+}
+
+func (s *MediaService) SetHideWatched(hide bool) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.HideWatched = hide
+	})
+}
+
+func (s *MediaService) HideWatchedIn(scope string) bool {
+	settings := s.store.GetSettings()
+	if hide, ok := settings.HideWatchedViews[scope]; ok {
+		return hide
+	}
+	return settings.HideWatched
+}
+
+func (s *MediaService) SetHideWatchedIn(scope string, hide bool) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		if hide == settings.HideWatched {
+			delete(settings.HideWatchedViews, scope)
+			return
+		}
+		if settings.HideWatchedViews == nil {
+			settings.HideWatchedViews = make(map[string]bool)
+		}
+		settings.HideWatchedViews[scope] = hide
+	})
+}
+
+func unplayedOnly(items []api.MediaItem) ([]api.MediaItem, int) {
+	unplayed := make([]api.MediaItem, 0, len(items))
+	for _, item := range items {
+		if item.UserData == nil || !item.UserData.Played {
+			unplayed = append(unplayed, item)
+		}
+	}
+	return unplayed, len(items) - len(unplayed)
+}
+
+func (s *MediaService) PrewarmImages() bool {
+	return s.store.GetSettings().PrewarmImages
+}
+
+func (s *MediaService) SetPrewarmImages(enabled bool) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.PrewarmImages = enabled
+	})
+}
+
+func (s *MediaService) StorageBackend() string {
+	if name := s.store.GetSettings().StorageBackend; name != "" {
+		return name
+	}
+	return storage.BackendJSON
+}
+
+func (s *MediaService) SetStorageBackend(name string) error {
+	return s.store.SetStorageBackend(name)
+}
+
+func (s *MediaService) GetRecentlyReleased(page, pageSize int) (*MediaList, error) {
+	return s.cachedList(fmt.Sprintf("released:%d:%d:%d", page, pageSize, s.ReleaseWindowDays()), func() (*MediaList, error) {
+		if page < 0 {
+			page = 0
+		}
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+
+		since := time.Now().AddDate(0, 0, -s.ReleaseWindowDays())
+		items, total, err := s.client.GetReleasedSince(since, page*pageSize, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recent releases: %w", err)
+		}
+
+		return &MediaList{
+			Items:    s.convertItems(items),
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
+const (
+	latestLimit           = 40
+	latestPerLibraryLimit = 12
+)
+
+func (s *MediaService) GetLatest(byLibrary bool) (*MediaList, error) {
+	return s.cachedList(fmt.Sprintf("latest:%t", byLibrary), func() (*MediaList, error) {
+		if !byLibrary {
+			items, err := s.client.GetLatest("", latestLimit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get recently added: %w", err)
+			}
+			converted := s.convertItems(items)
+			return &MediaList{Items: converted, Total: len(converted), PageSize: len(converted)}, nil
+		}
+
+		libraries, err := s.client.GetLibraries()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get libraries: %w", err)
+		}
+		var converted []MediaItem
+		for _, lib := range libraries {
+			items, err := s.client.GetLatest(lib.ID, latestPerLibraryLimit)
+			if err != nil {
+				continue
+			}
+			for _, item := range s.convertItems(items) {
+				item.Library = lib.Name
+				converted = append(converted, item)
+			}
+		}
+		return &MediaList{Items: converted, Total: len(converted), PageSize: len(converted)}, nil
+	})
 }
 
 func (s *MediaService) GetItem(itemID string) (*MediaItem, error) {
@@ -209,9 +697,50 @@ func (s *MediaService) GetItem(itemID string) (*MediaItem, error) {
 	}
 
 	converted := s.convertItem(*item)
+	if converted.Type == "Series" && converted.Status != "Ended" {
+		if next, err := s.client.GetNextAiring(itemID); err == nil && next != nil {
+			converted.NextAirDate = next.PremiereDate
+			converted.NextEpisode = episodeLabel(next.ParentIndexNumber, next.IndexNumber, next.Name)
+		}
+	}
 	return &converted, nil
 }
 
+func episodeLabel(season, episode int, name string) string {
+	if season > 0 && episode > 0 {
+		return fmt.Sprintf("S%02dE%02d %s", season, episode, name)
+	}
+	return name
+}
+
+func (s *MediaService) GetAiringSoon(page, pageSize int) (*MediaList, error) {
+	return s.cachedList(fmt.Sprintf("upcoming:%d:%d", page, pageSize), func() (*MediaList, error) {
+		if page < 0 {
+			page = 0
+		}
+		if pageSize <= 0 {
+			pageSize = 20
+		}
+
+		items, total, err := s.client.GetUpcoming(page*pageSize, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upcoming episodes: %w", err)
+		}
+
+		converted := s.convertItems(items)
+		for i := range converted {
+			converted[i].Playable = false
+		}
+		return &MediaList{
+			Items:    converted,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+			HasMore:  (page+1)*pageSize < total,
+		}, nil
+	})
+}
+
 func (s *MediaService) GetStreamInfo(itemID string) (*StreamInfo, error) {
 	item, err := s.client.GetItem(itemID)
 	if err != nil {
@@ -222,31 +751,58 @@ func (s *MediaService) GetStreamInfo(itemID string) (*StreamInfo, error) {
 }
 
 func (s *MediaService) GetStreamInfoForItem(item MediaItem) (*StreamInfo, error) {
-	if len(item.MediaSources) == 0 {
+	live := item.Type == "TvChannel"
+	if len(item.MediaSources) == 0 && !live {
 		return nil, fmt.Errorf("no media source available")
 	}
 
-	ms := item.MediaSources[0]
+	ms := MediaSource{Container: "ts"}
+	if len(item.MediaSources) > 0 {
+		ms, _ = s.PreferredSource(item)
+	}
+	s.cacheItem(item)
 	isFav := item.UserData != nil && item.UserData.IsFavorite
-	subtitleURLs := make([]string, 0, len(ms.Subtitles))
-	for _, subtitle := range ms.Subtitles {
+	s.store.SetItemMeta(storage.ItemMeta{
+		ItemID:     item.ID,
+		Name:       item.Name,
+		Type:       item.Type,
+		SeriesID:   item.SeriesID,
+		SeriesName: item.SeriesName,
+		SeasonID:   item.SeasonID,
+		SeasonName: item.SeasonName,
+	})
+	subtitles := slices.Clone(ms.Subtitles)
+	sortByLanguage(subtitles, func(sub SubtitleInfo) string { return sub.Language }, s.SubtitleLangs())
+	subtitleURLs := make([]string, 0, len(subtitles))
+	for _, subtitle := range subtitles {
 		if !subtitle.IsExternal {
 			continue
 		}
 		subtitleURLs = append(subtitleURLs, s.client.SubtitleURL(item.ID, ms.ID, subtitle.Index, subtitle.Codec))
 	}
 
+	audioIndex := preferredAudioIndex(ms.Audio, s.AudioLangs())
+	streamURL, playMethod, err := s.resolveStream(item.ID, ms.ID, ms.Container, audioIndex, live)
+	if err != nil {
+		return nil, err
+	}
+	positionSec := s.playbackPosition(item)
+	if live {
+		positionSec = 0
+	}
+
 	return &StreamInfo{
 		ItemID:        item.ID,
 		Name:          item.Name,
 		SeriesID:      item.SeriesID,
 		SeriesName:    item.SeriesName,
 		Type:          item.Type,
-		StreamURL:     s.client.StreamURL(item.ID, ms.ID, ms.Container),
+		StreamURL:     streamURL,
+		PlayMethod:    playMethod,
 		PosterURL:     s.client.ImageURLByID(item.ID, 800),
 		Container:     ms.Container,
 		Duration:      item.RunTimeTicks,
-		PositionSec:   s.playbackPosition(item),
+		PositionSec:   positionSec,
 		Subtitles:     ms.Subtitles,
 		SubtitleURLs:  subtitleURLs,
 		IsFavorite:    isFav,
@@ -254,15 +810,85 @@ func (s *MediaService) GetStreamInfoForItem(item MediaItem) (*StreamInfo, error)
 	}, nil
 }
 
+func (s *MediaService) resolveStream(itemID, sourceID, container string, audioIndex int, live bool) (string, string, error) {
+	if err := s.checkAccess(time.Now()); err != nil {
+		return "", "", err
+	}
+	if path, ok := s.LocalPath(itemID); ok {
+		return path, playMethodLocal, nil
+	}
+
+	staticURL := s.client.StreamURL(itemID, sourceID, container)
+
+	maxBitrate := s.MaxBitrate()
+	if _, ok := s.preferDirect.Load(itemID); ok {
+		maxBitrate = 0
+	}
+	info, err := s.client.GetPlaybackInfo(itemID, sourceID, maxBitrate, audioIndex, live)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 0 {
+			return "", "", fmt.Errorf("playback not allowed: %w", err)
+		}
+		return staticURL, playMethodDirect, nil
+	}
+
+	for _, ms := range info.MediaSources {
+		if sourceID != "" && ms.ID != sourceID {
+			continue
+		}
+		if ms.SupportsDirectPlay || ms.SupportsDirectStream || ms.TranscodingURL == "" {
+			if ms.LiveStreamID != "" {
+				s.liveStreams.Store(itemID, ms.LiveStreamID)
+				return s.client.LiveStreamURL(itemID, ms.ID, ms.LiveStreamID, ms.Container), playMethodDirect, nil
+			}
+			return s.client.StreamURL(itemID, ms.ID, ms.Container), playMethodDirect, nil
+		}
+		return s.client.TranscodeURL(ms.TranscodingURL), playMethodTranscode, nil
+	}
+	return staticURL, playMethodDirect, nil
+}
+
 func (s *MediaService) playbackPosition(item MediaItem) int64 {
-	positionSec := s.store.GetPlaybackPosition(item.ID)
-	if positionSec > 0 {
-		return positionSec
+	var lastWatched time.Time
+	if item.UserData != nil {
+		lastWatched, _ = time.Parse(time.RFC3339, item.UserData.LastPlayedDate)
+	}
+	if detail, ok := s.store.GetMediaDetail(item.ID); ok && detail.PositionSec > 0 {
+		if t, err := time.Parse(time.RFC3339, detail.UpdatedAt); err == nil {
+			lastWatched = t
+		}
+		return s.rewindResume(detail.PositionSec, lastWatched)
 	}
 	if item.UserData == nil || item.UserData.PlaybackPositionTicks <= 0 {
 		return 0
 	}
-	return item.UserData.PlaybackPositionTicks / 10000000
+	return s.rewindResume(item.UserData.PlaybackPositionTicks/10000000, lastWatched)
+}
+
+func (s *MediaService) storedResumePosition(itemID string) int64 {
+	detail, ok := s.store.GetMediaDetail(itemID)
+	if !ok || detail.PositionSec <= 0 {
+		return 0
+	}
+	lastWatched, _ := time.Parse(time.RFC3339, detail.UpdatedAt)
+	return s.rewindResume(detail.PositionSec, lastWatched)
+}
+
+func (s *MediaService) rewindResume(positionSec int64, lastWatched time.Time) int64 {
+	rewind := int64(s.ResumeRewind())
+	if rewind <= 0 || positionSec <= 0 {
+		return positionSec
+	}
+	if !lastWatched.IsZero() {
+		switch away := time.Since(lastWatched); {
+		case away >= 7*24*time.Hour:
+			rewind *= 3
+		case away >= 24*time.Hour:
+			rewind *= 2
+		}
+	}
+	return max(positionSec-rewind, 0)
 }
 
 func (s *MediaService) ReportPlayback(req PlaybackRequest) error {
@@ -270,17 +896,24 @@ func (s *MediaService) ReportPlayback(req PlaybackRequest) error {
 
 	switch req.Type {
 	case "start":
-		return s.client.ReportPlaybackStart(req.ItemID, "", sessionID, req.PositionTicks)
+		err := s.client.ReportPlaybackStart(req.ItemID, "", s.liveStreamID(req.ItemID), sessionID, req.PositionTicks)
+		if err == nil {
+			s.audit(AuditPlayback, s.auditItemName(req.ItemID))
+			s.events.Publish(Event{Type: EventPlaybackStarted, ItemID: req.ItemID, PositionSec: req.PositionTicks / 10000000})
+		}
+		return err
 	case "progress":
-		return s.client.ReportPlaybackProgress(req.ItemID, "", sessionID, req.PositionTicks, false)
+		return s.client.ReportPlaybackProgress(req.ItemID, "", s.liveStreamID(req.ItemID), sessionID, req.PositionTicks, false)
 	case "stop":
-		err := s.client.ReportPlaybackStopped(req.ItemID, "", sessionID, req.PositionTicks)
+		err := s.client.ReportPlaybackStopped(req.ItemID, "", s.liveStreamID(req.ItemID), sessionID, req.PositionTicks)
+		s.closeLiveStream(req.ItemID)
 		if err == nil {
 			durationSec := int64(0)
 			if item, e := s.client.GetItem(req.ItemID); e == nil {
 				durationSec = item.RunTimeTicks / 10000000
 			}
 			s.store.UpdatePlaybackPosition(req.ItemID, req.PositionTicks/10000000, durationSec)
+			s.events.Publish(Event{Type: EventPlaybackStopped, ItemID: req.ItemID, PositionSec: req.PositionTicks / 10000000})
 		}
 		return err
 	default:
@@ -288,6 +921,23 @@ func (s *MediaService) ReportPlayback(req PlaybackRequest) error {
 	}
 }
 
+func (s *MediaService) DiagnosePlayback(streamURL string, cause error) error {
+	if cause == nil {
+		return nil
+	}
+	if !strings.HasPrefix(streamURL, "http") {
+		return cause
+	}
+	if err := s.client.ProbeStream(streamURL); err != nil {
+		return err
+	}
+	return cause
+}
+
+func ErrorRemedy(err error) string {
+	return api.Remedy(err)
+}
+
 func (s *MediaService) SetFavorite(itemID string, favorite bool) (*FavoriteResult, error) {
 	var err error
 	if favorite {
@@ -298,6 +948,7 @@ func (s *MediaService) SetFavorite(itemID string, favorite bool) (*FavoriteResul
 	if err != nil {
 		state, statusErr := s.client.IsFavorite(itemID)
 		if statusErr == nil && state == favorite {
+			s.publishFavorite(itemID, favorite)
 			return &FavoriteResult{IsFavorite: favorite}, nil
 		}
 		if favorite {
@@ -308,11 +959,45 @@ func (s *MediaService) SetFavorite(itemID string, favorite bool) (*FavoriteResul
 
 	finalState, statusErr := s.client.IsFavorite(itemID)
 	if statusErr != nil {
-		return &FavoriteResult{IsFavorite: favorite}, nil
+		finalState = favorite
 	}
+	s.publishFavorite(itemID, finalState)
 	return &FavoriteResult{IsFavorite: finalState}, nil
 }
 
+func (s *MediaService) publishFavorite(itemID string, favorite bool) {
+	s.events.Publish(Event{Type: EventFavoriteChanged, ItemID: itemID, Favorite: favorite})
+}
+
+func (s *MediaService) SetPlayed(itemID string, played bool) (*PlayedResult, error) {
+	if played {
+		if err := s.client.MarkPlayed(itemID); err != nil {
+			return nil, fmt.Errorf("failed to mark played: %w", err)
+		}
+		s.store.UpdatePlaybackPosition(itemID, 0, 0)
+		s.events.Publish(Event{Type: EventPlayedChanged, ItemID: itemID, Played: true})
+		return &PlayedResult{Played: true}, nil
+	}
+
+	if err := s.client.MarkUnplayed(itemID); err != nil {
+		return nil, fmt.Errorf("failed to mark unplayed: %w", err)
+	}
+	s.events.Publish(Event{Type: EventPlayedChanged, ItemID: itemID, Played: false})
+	return &PlayedResult{Played: false}, nil
+}
+
+func (s *MediaService) SetRating(itemID string, likes *bool) (*RatingResult, error) {
+	if likes == nil {
+		if err := s.client.ClearRating(itemID); err != nil {
+			return nil, fmt.Errorf("failed to clear rating: %w", err)
+		}
+	} else if err := s.client.SetLikes(itemID, *likes); err != nil {
+		return nil, fmt.Errorf("failed to submit rating: %w", err)
+	}
+	s.events.Publish(Event{Type: EventRatingChanged, ItemID: itemID, Likes: likes})
+	return &RatingResult{Likes: likes}, nil
+}
+
 func (s *MediaService) ToggleFavorite(itemID string) (*FavoriteResult, error) {
 	isFav, err := s.client.IsFavorite(itemID)
 	if err != nil {
@@ -323,12 +1008,105 @@ func (s *MediaService) ToggleFavorite(itemID string) (*FavoriteResult, error) {
 }
 
 func (s *MediaService) ReportPlaybackStart(itemID, mediaSourceID, sessionID string, positionSec int64) error {
-	return s.client.ReportPlaybackStart(itemID, mediaSourceID, sessionID, positionSec*10_000_000)
+	s.beginPlaybackSession(itemID, sessionID, positionSec)
+	s.audit(AuditPlayback, s.auditItemName(itemID))
+	s.events.Publish(Event{Type: EventPlaybackStarted, ItemID: itemID, PositionSec: positionSec})
+	return s.client.ReportPlaybackStart(itemID, mediaSourceID, s.liveStreamID(itemID), sessionID, positionSec*10_000_000)
+}
+
+func (s *MediaService) ReportPlaybackProgress(itemID, mediaSourceID, sessionID string, positionSec int64) error {
+	return s.client.ReportPlaybackProgress(itemID, mediaSourceID, s.liveStreamID(itemID), sessionID, positionSec*10_000_000, false)
 }
 
 func (s *MediaService) ReportPlaybackStopped(itemID, mediaSourceID, sessionID string, positionSec, durationTicks int64) error {
-	s.store.UpdatePlaybackPosition(itemID, positionSec, durationTicks/10_000_000)
-	return s.client.ReportPlaybackStopped(itemID, mediaSourceID, sessionID, positionSec*10_000_000)
+	durationSec := durationTicks / 10_000_000
+	finished := s.reachedPlayedThreshold(positionSec, durationSec)
+	if finished {
+		s.store.UpdatePlaybackPosition(itemID, 0, durationSec)
+	} else {
+		s.store.UpdatePlaybackPosition(itemID, positionSec, durationSec)
+	}
+	s.recordPlayback(itemID, sessionID, positionSec, durationSec)
+	s.events.Publish(Event{Type: EventPlaybackStopped, ItemID: itemID, PositionSec: positionSec})
+	err := s.client.ReportPlaybackStopped(itemID, mediaSourceID, s.liveStreamID(itemID), sessionID, positionSec*10_000_000)
+	s.closeLiveStream(itemID)
+	if err != nil && shouldQueueReport(err) {
+		s.queueStoppedReport(itemID, mediaSourceID, sessionID, positionSec, durationSec)
+		return fmt.Errorf("report queued for later sync: %w", err)
+	}
+	if err == nil && finished {
+		s.markFinished(itemID)
+	}
+	if err == nil {
+		go s.creditCoveredEpisodes(itemID, positionSec, durationSec, finished)
+	}
+	return err
+}
+
+func (s *MediaService) liveStreamID(itemID string) string {
+	id, _ := s.liveStreams.Load(itemID)
+	liveStreamID, _ := id.(string)
+	return liveStreamID
+}
+
+// closeLiveStream releases the tuner of a live channel once its playback
+// is over; the server would otherwise hold it until its own timeout.
+func (s *MediaService) closeLiveStream(itemID string) {
+	id, ok := s.liveStreams.LoadAndDelete(itemID)
+	if !ok {
+		return
+	}
+	if err := s.client.CloseLiveStream(id.(string)); err != nil {
+		logging.Player("Closing live stream failed", "item", itemID, "error", err)
+	}
+}
+
+func (s *MediaService) reachedPlayedThreshold(positionSec, durationSec int64) bool {
+	threshold := s.PlayedThreshold()
+	if threshold < 0 || durationSec <= 0 {
+		return false
+	}
+	return positionSec*100 >= durationSec*int64(threshold)
+}
+
+func (s *MediaService) markFinished(itemID string) {
+	if err := s.client.MarkPlayed(itemID); err != nil {
+		logging.Player("Auto mark played failed", "item", itemID, "error", err)
+		return
+	}
+	s.events.Publish(Event{Type: EventPlayedChanged, ItemID: itemID, Played: true})
+}
+
+// creditCoveredEpisodes passes the progress of a multi-episode file on to
+// the episodes it covers past the first. It lists the season over the
+// network, so callers on the stop path run it in the background.
+func (s *MediaService) creditCoveredEpisodes(itemID string, positionSec, durationSec int64, finished bool) {
+	item, ok := s.cachedItem(itemID)
+	if !ok || item.IndexNumber <= 0 || item.IndexEnd <= item.IndexNumber || item.SeriesID == "" || durationSec <= 0 {
+		return
+	}
+
+	episodes, err := s.client.GetEpisodes(item.SeriesID, item.SeasonID, false)
+	if err != nil {
+		logging.Player("Listing covered episodes failed", "item", itemID, "error", err)
+		return
+	}
+
+	share := durationSec / int64(item.IndexEnd-item.IndexNumber+1)
+	for _, ep := range episodes {
+		if ep.ID == itemID || ep.IndexNumber <= item.IndexNumber || ep.IndexNumber > item.IndexEnd {
+			continue
+		}
+		watched := positionSec - int64(ep.IndexNumber-item.IndexNumber)*share
+		switch {
+		case finished || s.reachedPlayedThreshold(watched, share):
+			s.markFinished(ep.ID)
+		case watched > 0:
+			if err := s.client.SetPlaybackPosition(ep.ID, watched*10_000_000); err != nil {
+				logging.Player("Reporting covered episode failed", "item", ep.ID, "error", err)
+			}
+		}
+	}
 }
 
 func (s *MediaService) BuildContinuousPlayback(item MediaItem) (*ContinuousPlaybackPlan, error) {
@@ -341,7 +1119,7 @@ func (s *MediaService) BuildContinuousPlayback(item MediaItem) (*ContinuousPlayb
 		return nil, fmt.Errorf("missing season info")
 	}
 
-	episodes, err := s.client.GetEpisodes(seriesID, seasonID)
+	episodes, err := s.client.GetEpisodes(seriesID, seasonID, false)
 	if err != nil {
 		return nil, err
 	}
@@ -357,19 +1135,73 @@ func (s *MediaService) BuildContinuousPlayback(item MediaItem) (*ContinuousPlayb
 		}
 	}
 
-	urls := make([]string, 0, len(episodes)-startIndex)
+	ids := make([]string, 0, len(episodes)-startIndex)
+	for _, ep := range episodes[startIndex:] {
+		ids = append(ids, ep.ID)
+	}
+
+	title := item.SeriesName
+	if title == "" {
+		title = item.Name
+	}
+	return s.buildPlan(title, ids)
+}
+
+func (s *MediaService) BuildAlbumPlayback(item MediaItem) (*ContinuousPlaybackPlan, error) {
+	albumID := item.AlbumID
+	if albumID == "" {
+		albumID = item.ParentID
+	}
+	if albumID == "" {
+		return s.buildPlan(item.Name, []string{item.ID})
+	}
+
+	tracks, err := s.client.GetAlbumTracks(albumID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get album tracks: %w", err)
+	}
+	var ids []string
+	for _, track := range tracks {
+		if track.ID == item.ID {
+			ids = ids[:0]
+		}
+		ids = append(ids, track.ID)
+	}
+	if len(ids) == 0 || ids[0] != item.ID {
+		ids = []string{item.ID}
+	}
+
+	title := item.Album
+	if title == "" {
+		title = item.Name
+	}
+	return s.buildPlan(title, ids)
+}
+
+func (s *MediaService) buildPlan(title string, ids []string) (*ContinuousPlaybackPlan, error) {
+	urls := make([]string, 0, len(ids))
+	var items []MediaItem
+	var meta []player.Metadata
 	var currentItem MediaItem
 	currentSet := false
-	for i := startIndex; i < len(episodes); i++ {
-		epFull, err := s.client.GetItem(episodes[i].ID)
+	for _, id := range ids {
+		epFull, err := s.client.GetItem(id)
 		if err != nil || len(epFull.MediaSources) == 0 {
 			continue
 		}
 
-		ms := epFull.MediaSources[0]
-		urls = append(urls, s.client.StreamURL(epFull.ID, ms.ID, ms.Container))
+		converted := s.convertItem(*epFull)
+		ms, _ := s.PreferredSource(converted)
+		streamURL, _, err := s.resolveStream(epFull.ID, ms.ID, ms.Container, preferredAudioIndex(ms.Audio, s.AudioLangs()), false)
+		if err != nil {
+			continue
+		}
+		s.cacheItem(converted)
+		urls = append(urls, streamURL)
+		items = append(items, converted)
+		meta = append(meta, s.PlayerMetadata(converted))
 		if !currentSet {
-			currentItem = s.convertItem(*epFull)
+			currentItem = converted
 			currentSet = true
 		}
 	}
@@ -386,15 +1218,16 @@ func (s *MediaService) BuildContinuousPlayback(item MediaItem) (*ContinuousPlayb
 		return nil, err
 	}
 
-	title := item.SeriesName
-	if title == "" {
-		title = item.Name
+	for i := 0; i+1 < len(meta); i++ {
+		meta[i].Next = meta[i+1].Title
 	}
 
 	return &ContinuousPlaybackPlan{
 		Title:       title,
 		StartIndex:  0,
 		URLs:        urls,
+		Items:       items,
+		Metadata:    meta,
 		CurrentItem: currentItem,
 		StreamInfo:  streamInfo,
 	}, nil
@@ -504,6 +1337,8 @@ func (s *MediaService) GetServers() []ServerInfo {
 			Username: srv.Username,
 			IsActive: i == activeIdx,
 			Prefix:   srv.Prefix(),
+
+			AuthFailed: srv.AuthFailed,
 		}
 	}
 
@@ -520,6 +1355,10 @@ func (s *MediaService) GetActiveServer() *ServerInfo {
 }
 
 func (s *MediaService) AddServer(name, url, username, password string) error {
+	url, err := NormalizeServerURL(url)
+	if err != nil {
+		return err
+	}
 	srv := storage.Server{
 		Name:     name,
 		URL:      url,
@@ -528,14 +1367,15 @@ func (s *MediaService) AddServer(name, url, username, password string) error {
 	}
 
 	client := api.New(srv.URL)
-	if err := client.Login(username, password); err != nil {
-		return fmt.Errorf("login failed: %w", err)
+	if err := Login(client, username, password); err != nil {
+		return err
 	}
 
 	srv.UserID = client.UserID
 	srv.Token = client.Token
 
 	s.store.AddServer(srv)
+	s.audit(AuditServerAdd, name)
 
 	if len(s.store.GetServers()) == 1 {
 		s.store.SetActiveServer(0)
@@ -551,7 +1391,13 @@ func (s *MediaService) UpdateServer(index int, name, url, username, password str
 		return fmt.Errorf("server not found")
 	}
 
+	url, err := NormalizeServerURL(url)
+	if err != nil {
+		return err
+	}
+
 	srv := servers[index]
+	relogin := srv.URL != url || srv.Username != username || password != "" || srv.AuthFailed
 	srv.Name = name
 	srv.URL = url
 	srv.Username = username
@@ -559,7 +1405,21 @@ func (s *MediaService) UpdateServer(index int, name, url, username, password str
 		srv.Password = password
 	}
 
+	if relogin {
+		client := api.New(srv.URL)
+		if err := Login(client, srv.Username, srv.Password); err != nil {
+			return err
+		}
+		srv.UserID = client.UserID
+		srv.Token = client.Token
+		srv.AuthFailed = false
+		if index == s.store.GetActiveServerIndex() {
+			s.client = client
+		}
+	}
+
 	s.store.UpdateServer(index, srv)
+	s.audit(AuditServerUpdate, srv.Name)
 	return nil
 }
 
@@ -570,6 +1430,7 @@ func (s *MediaService) DeleteServer(index int) error {
 	}
 
 	s.store.DeleteServer(index)
+	s.audit(AuditServerDelete, servers[index].Name)
 	return nil
 }
 
@@ -587,13 +1448,37 @@ func (s *MediaService) ActivateServer(index int) error {
 	client.Token = srv.Token
 
 	if !client.VerifyToken() {
-		if err := client.Login(srv.Username, srv.Password); err != nil {
-			return fmt.Errorf("login failed: %w", err)
+		if srv.AuthFailed {
+			return ErrLoginSuspended
+		}
+		if err := Login(client, srv.Username, srv.Password); err != nil {
+			if errors.Is(err, ErrWrongPassword) {
+				s.store.SetServerAuthFailed(index, true)
+			}
+			return err
 		}
 		s.store.SaveServerToken(index, client.UserID, client.Token)
 	}
 
 	s.client = client
+	s.audit(AuditServerSwitch, srv.Name)
+	s.events.Publish(Event{Type: EventServerSwitched, Server: srv.Name})
+	return nil
+}
+
+func (s *MediaService) MaxBitrate() int {
+	srv := s.store.GetActiveServer()
+	if srv == nil {
+		return 0
+	}
+	return srv.MaxBitrate
+}
+
+func (s *MediaService) SetMaxBitrate(bitrate int) error {
+	if s.store.GetActiveServer() == nil {
+		return fmt.Errorf("no active server")
+	}
+	s.store.SetServerMaxBitrate(s.store.GetActiveServerIndex(), bitrate)
 	return nil
 }
 
@@ -615,7 +1500,7 @@ func (s *MediaService) GetServerStatus() *ServerStatus {
 			Username: srv.Username,
 			Prefix:   srv.Prefix(),
 		}
-		status.Connected = s.client.VerifyToken()
+		status.Connected, status.Maintenance = s.checkMaintenance()
 		status.Latency = s.client.Latency.Milliseconds()
 	}
 
@@ -656,7 +1541,10 @@ func (s *MediaService) PlayWithMPV(itemID string) (*PlayResult, error) {
 	}
 
 	ms := item.MediaSources[0]
-	streamURL := s.client.StreamURL(itemID, ms.ID, ms.Container)
+	streamURL, _, err := s.resolveStream(itemID, ms.ID, ms.Container, -1, false)
+	if err != nil {
+		return nil, err
+	}
 
 	var subtitleURLs []string
 	for _, stream := range ms.MediaStreams {
@@ -667,10 +1555,10 @@ func (s *MediaService) PlayWithMPV(itemID string) (*PlayResult, error) {
 		}
 	}
 
-	positionSec := s.store.GetPlaybackPosition(itemID)
+	positionSec := s.storedResumePosition(itemID)
 
 	go func() {
-		result := player.Play(streamURL, item.Name, subtitleURLs, positionSec)
+		result := player.Play(streamURL, item.Name, subtitleURLs, positionSec, s.PlayerMetadata(s.convertItem(*item)))
 		if result.Err != nil {
 			return
 		}
@@ -697,7 +1585,7 @@ func (s *MediaService) GetSeriesPlaylist(seriesID string) (*EpisodePlaylist, err
 
 	var allEpisodes []PlaylistEpisode
 	for _, season := range seasons {
-		episodes, err := s.client.GetEpisodes(seriesID, season.ID)
+		episodes, err := s.client.GetEpisodes(seriesID, season.ID, false)
 		if err != nil {
 			continue
 		}
@@ -727,6 +1615,9 @@ func (s *MediaService) PlaySeriesWithMPV(seriesID, startEpisodeID string) (*Play
 	if !player.Available() {
 		return nil, fmt.Errorf("mpv player not available")
 	}
+	if err := s.checkAccess(time.Now()); err != nil {
+		return nil, err
+	}
 
 	playlist, err := s.GetSeriesPlaylist(seriesID)
 	if err != nil {
@@ -748,16 +1639,20 @@ func (s *MediaService) PlaySeriesWithMPV(seriesID, startEpisodeID string) (*Play
 
 	positionSec := int64(0)
 	if startIndex < len(playlist.Episodes) {
-		positionSec = s.store.GetPlaybackPosition(playlist.Episodes[startIndex].ItemID)
+		positionSec = s.storedResumePosition(playlist.Episodes[startIndex].ItemID)
 	}
 
 	go func() {
-		result := player.PlayMultiple(urls, playlist.SeriesName, nil, positionSec, startIndex)
+		result := player.PlayMultipleTracked(urls, playlist.SeriesName, nil, positionSec, startIndex, nil, func(from, _ int, positionSec int64) {
+			if from >= 0 && from < len(playlist.Episodes) {
+				s.store.UpdatePlaybackPosition(playlist.Episodes[from].ItemID, positionSec, 0)
+			}
+		})
 		if result.Err != nil {
 			return
 		}
-		if startIndex < len(playlist.Episodes) {
-			s.store.UpdatePlaybackPosition(playlist.Episodes[startIndex].ItemID, result.PositionSec, 0)
+		if result.Index >= 0 && result.Index < len(playlist.Episodes) {
+			s.store.UpdatePlaybackPosition(playlist.Episodes[result.Index].ItemID, result.PositionSec, 0)
 		}
 	}()
 
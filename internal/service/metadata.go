@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"ember/internal/player"
+)
+
+func MediaTitle(item MediaItem) string {
+	if item.Type == "Episode" && item.SeriesName != "" && item.IndexEnd > item.IndexNumber {
+		return fmt.Sprintf("%s - S%02dE%02d-E%02d - %s", item.SeriesName, item.SeasonNumber, item.IndexNumber, item.IndexEnd, item.Name)
+	}
+	if item.Type == "Episode" && item.SeriesName != "" {
+		return fmt.Sprintf("%s - S%02dE%02d - %s", item.SeriesName, item.SeasonNumber, item.IndexNumber, item.Name)
+	}
+	if item.Type == "Audio" && item.Artist != "" {
+		return item.Artist + " - " + item.Name
+	}
+	if item.Year > 0 {
+		return fmt.Sprintf("%s (%d)", item.Name, item.Year)
+	}
+	return item.Name
+}
+
+func (s *MediaService) PlayerMetadata(item MediaItem) player.Metadata {
+	meta := player.Metadata{Title: MediaTitle(item), Audio: item.Type == "Audio"}
+	for _, ch := range item.Chapters {
+		meta.Chapters = append(meta.Chapters, player.Chapter{Title: ch.Name, StartSec: ch.StartSec})
+	}
+	if item.Type == "Episode" && item.SeriesID != "" {
+		meta.TrackKey = item.SeriesID
+		meta.Tracks = s.seriesTracks(item.SeriesID)
+	}
+	return meta
+}
+
+// MPVArgs returns the mpv arguments from settings with the overrides from
+// the config file and command line applied on top.
+func (s *MediaService) MPVArgs() player.Args {
+	args := playerArgs(s.store.GetSettings())
+
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	if s.mpvProfile != "" {
+		args.Profile = s.mpvProfile
+	}
+	args.Extra = append(slices.Clip(args.Extra), s.mpvExtra...)
+	return args
+}
+
+// OverrideMPVArgs layers profile and extra over the settings. They are
+// kept on the service, so later SetArgs calls (like toggling forced
+// subtitles) don't drop them.
+func (s *MediaService) OverrideMPVArgs(profile string, extra []string) {
+	s.overrideMu.Lock()
+	if profile != "" {
+		s.mpvProfile = profile
+	}
+	s.mpvExtra = append(s.mpvExtra, extra...)
+	s.overrideMu.Unlock()
+	player.SetArgs(s.MPVArgs())
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"sort"
+
+	"ember/internal/logging"
+)
+
+func (s *MediaService) Offline() bool {
+	return s.offline.Load()
+}
+
+func isUnreachable(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func (s *MediaService) cachedList(key string, fetch func() (*MediaList, error)) (*MediaList, error) {
+	list, err := fetch()
+	if err == nil {
+		s.offline.Store(false)
+		s.clearFinishedPositions(list.Items)
+		if data, err := json.Marshal(list); err == nil {
+			if err := s.store.SetCachedListing(key, data); err != nil {
+				logging.Storage("Failed to cache listing", "key", key, "error", err)
+			}
+		}
+		return list, nil
+	}
+	if !isUnreachable(err) && !IsMaintenanceError(err) {
+		return nil, err
+	}
+
+	s.offline.Store(true)
+	data, ok := s.store.GetCachedListing(key)
+	if !ok {
+		return nil, err
+	}
+	var cached MediaList
+	if json.Unmarshal(data, &cached) != nil {
+		return nil, err
+	}
+	logging.Storage("Serving cached listing", "key", key)
+	return &cached, nil
+}
+
+func (s *MediaService) clearFinishedPositions(items []MediaItem) {
+	var played []string
+	for _, item := range items {
+		if item.UserData != nil && item.UserData.Played && item.UserData.PlaybackPositionTicks == 0 {
+			played = append(played, item.ID)
+		}
+	}
+	if len(played) == 0 {
+		return
+	}
+	if n := s.store.ClearPlaybackPositions(played); n > 0 {
+		logging.Storage("Cleared positions of played items", "count", n)
+	}
+}
+
+func (s *MediaService) cacheItem(item MediaItem) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return
+	}
+	if err := s.store.SetCachedListing("item:"+item.ID, data); err != nil {
+		logging.Storage("Failed to cache item", "id", item.ID, "error", err)
+	}
+}
+
+func (s *MediaService) cachedItem(itemID string) (MediaItem, bool) {
+	data, ok := s.store.GetCachedListing("item:" + itemID)
+	if !ok {
+		return MediaItem{}, false
+	}
+	var item MediaItem
+	if json.Unmarshal(data, &item) != nil {
+		return MediaItem{}, false
+	}
+	return item, true
+}
+
+func (s *MediaService) GetDownloadedItems() *MediaList {
+	var items []MediaItem
+	for _, d := range s.store.GetDownloads() {
+		if !d.Complete {
+			continue
+		}
+		item, ok := s.cachedItem(d.ItemID)
+		if !ok {
+			item = MediaItem{
+				ID:           d.ItemID,
+				Name:         d.Name,
+				Type:         d.Type,
+				SeriesName:   d.SeriesName,
+				MediaSources: []MediaSource{{}},
+				Playable:     true,
+			}
+		}
+		item.Download = "unverified"
+		if d.Verified && verifyDownloadFile(d) == nil {
+			item.Download = "verified"
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return displayName(items[i]) < displayName(items[j])
+	})
+
+	return &MediaList{
+		Items:    items,
+		Total:    len(items),
+		Page:     0,
+		PageSize: len(items),
+		HasMore:  false,
+	}
+}
+
+func displayName(item MediaItem) string {
+	if item.SeriesName != "" {
+		return item.SeriesName + " " + item.Name
+	}
+	return item.Name
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+
+	"ember/internal/storage"
+)
+
+func (s *MediaService) Queue() []MediaItem {
+	entries := s.store.GetQueue()
+	items := make([]MediaItem, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, MediaItem{
+			ID:         e.ItemID,
+			Name:       e.Name,
+			Type:       e.Type,
+			SeriesName: e.SeriesName,
+			Playable:   true,
+		})
+	}
+	return items
+}
+
+func (s *MediaService) Enqueue(item MediaItem) (int, error) {
+	var entries []storage.QueueEntry
+	switch {
+	case item.Type == "Season":
+		seriesID := item.SeriesID
+		if seriesID == "" {
+			seriesID = item.ParentID
+		}
+		episodes, err := s.client.GetEpisodes(seriesID, item.ID, false)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get episodes: %w", err)
+		}
+		for _, ep := range episodes {
+			entries = append(entries, storage.QueueEntry{ItemID: ep.ID, Name: ep.Name, Type: ep.Type, SeriesName: ep.SeriesName})
+		}
+	case item.Playable:
+		entries = append(entries, storage.QueueEntry{ItemID: item.ID, Name: item.Name, Type: item.Type, SeriesName: item.SeriesName})
+	default:
+		return 0, fmt.Errorf("only seasons and playable items can be queued")
+	}
+
+	queue := s.store.GetQueue()
+	queued := make(map[string]bool, len(queue))
+	for _, e := range queue {
+		queued[e.ItemID] = true
+	}
+	added := 0
+	for _, e := range entries {
+		if queued[e.ItemID] {
+			continue
+		}
+		queued[e.ItemID] = true
+		queue = append(queue, e)
+		added++
+	}
+	s.store.SetQueue(queue)
+	return added, nil
+}
+
+func (s *MediaService) MoveQueued(index, delta int) int {
+	queue := s.store.GetQueue()
+	target := index + delta
+	if index < 0 || index >= len(queue) || target < 0 || target >= len(queue) {
+		return index
+	}
+	queue[index], queue[target] = queue[target], queue[index]
+	s.store.SetQueue(queue)
+	return target
+}
+
+func (s *MediaService) RemoveQueued(index int) {
+	queue := s.store.GetQueue()
+	if index < 0 || index >= len(queue) {
+		return
+	}
+	s.audit(AuditQueueRemove, queue[index].Name)
+	s.store.SetQueue(append(queue[:index], queue[index+1:]...))
+}
+
+func (s *MediaService) ClearQueue() {
+	s.audit(AuditQueueClear, "")
+	s.store.SetQueue(nil)
+}
+
+func (s *MediaService) BuildQueuePlayback(startIndex int) (*ContinuousPlaybackPlan, error) {
+	queue := s.store.GetQueue()
+	if startIndex < 0 || startIndex >= len(queue) {
+		return nil, fmt.Errorf("queue is empty")
+	}
+	ids := make([]string, 0, len(queue)-startIndex)
+	for _, e := range queue[startIndex:] {
+		ids = append(ids, e.ItemID)
+	}
+	return s.buildPlan("Queue", ids)
+}
+
+func (s *MediaService) FinishQueuePlayback(plan *ContinuousPlaybackPlan, lastIndex int, positionSec int64) {
+	done := make(map[string]bool)
+	for i, item := range plan.Items {
+		if i < lastIndex || (i == lastIndex && s.reachedPlayedThreshold(positionSec, item.RunTimeTicks/10_000_000)) {
+			done[item.ID] = true
+		}
+	}
+	if len(done) == 0 {
+		return
+	}
+
+	queue := s.store.GetQueue()
+	remaining := queue[:0]
+	for _, e := range queue {
+		if !done[e.ItemID] {
+			remaining = append(remaining, e)
+		}
+	}
+	s.store.SetQueue(remaining)
+}
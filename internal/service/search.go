@@ -0,0 +1,194 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	deepSearchMaxHints      = 5
+	deepSearchOverviewScan  = 1000
+	deepSearchOverviewLimit = 20
+)
+
+func (s *MediaService) DeepSearch(query string, limit int) (*MediaList, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	titles, err := s.SearchWithOptions(SearchQuery{Query: query, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var merged []MediaItem
+	add := func(items []MediaItem, matchType string) {
+		for _, item := range items {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			item.MatchType = matchType
+			merged = append(merged, item)
+		}
+	}
+	add(titles.Items, "title")
+
+	if hints, err := s.client.SearchHints(query, 20); err == nil {
+		people, studios := 0, 0
+		for _, hint := range hints {
+			switch hint.Type {
+			case "Person":
+				if people >= deepSearchMaxHints {
+					continue
+				}
+				people++
+				if items, err := s.client.GetItemsByPerson(hint.EntityID(), limit); err == nil {
+					add(s.convertItems(items), "person: "+hint.Name)
+				}
+			case "Studio":
+				if studios >= deepSearchMaxHints {
+					continue
+				}
+				studios++
+				if items, err := s.client.GetItemsByStudio(hint.EntityID(), limit); err == nil {
+					add(s.convertItems(items), "studio: "+hint.Name)
+				}
+			}
+		}
+	}
+
+	if candidates, err := s.client.GetOverviewCandidates(deepSearchOverviewScan); err == nil {
+		var matches []MediaItem
+		for _, item := range s.convertItems(candidates) {
+			if overviewMatches(item.Overview, query) {
+				matches = append(matches, item)
+				if len(matches) >= deepSearchOverviewLimit {
+					break
+				}
+			}
+		}
+		add(matches, "overview")
+	}
+
+	return &MediaList{
+		Items:    merged,
+		Total:    len(merged),
+		Page:     0,
+		PageSize: len(merged),
+		HasMore:  false,
+	}, nil
+}
+
+func overviewMatches(overview, query string) bool {
+	overview = strings.ToLower(overview)
+	words := strings.Fields(strings.ToLower(query))
+	if overview == "" || len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if !strings.Contains(overview, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *MediaService) ResolveItem(query string) (*MediaItem, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("empty item query")
+	}
+
+	if item, err := s.GetItem(query); err == nil {
+		return item, nil
+	}
+
+	list, err := s.Search(query, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	var fallback *MediaItem
+	for i := range list.Items {
+		item := &list.Items[i]
+		if !item.Playable {
+			continue
+		}
+		if strings.EqualFold(item.Name, query) {
+			return item, nil
+		}
+		if fallback == nil {
+			fallback = item
+		}
+	}
+	if fallback == nil {
+		return nil, fmt.Errorf("no playable item matches %q", query)
+	}
+	return fallback, nil
+}
+
+const (
+	recentWatchWindow = 30 * 24 * time.Hour
+	olderWatchWindow  = 90 * 24 * time.Hour
+)
+
+func (s *MediaService) rankByLocalSignals(items []MediaItem) []MediaItem {
+	if len(items) < 2 {
+		return items
+	}
+
+	lastWatched := make(map[string]time.Time)
+	for _, detail := range s.store.GetMediaDetails() {
+		t, err := time.Parse(time.RFC3339, detail.UpdatedAt)
+		if err != nil {
+			continue
+		}
+		ids := []string{detail.ItemID}
+		if meta, ok := s.store.GetItemMeta(detail.ItemID); ok && meta.SeriesID != "" {
+			ids = append(ids, meta.SeriesID)
+		}
+		for _, id := range ids {
+			if t.After(lastWatched[id]) {
+				lastWatched[id] = t
+			}
+		}
+	}
+
+	now := time.Now()
+	score := func(item MediaItem) int {
+		n := 0
+		if ud := item.UserData; ud != nil {
+			if ud.PlaybackPositionTicks > 0 && !ud.Played {
+				n += 3
+			}
+			if ud.IsFavorite {
+				n += 2
+			}
+		}
+		watched := lastWatched[item.ID]
+		if t := lastWatched[item.SeriesID]; item.SeriesID != "" && t.After(watched) {
+			watched = t
+		}
+		switch age := now.Sub(watched); {
+		case watched.IsZero():
+		case age <= recentWatchWindow:
+			n += 3
+		case age <= olderWatchWindow:
+			n++
+		}
+		return n
+	}
+
+	scores := make(map[string]int, len(items))
+	for _, item := range items {
+		scores[item.ID] = score(item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return scores[items[i].ID] > scores[items[j].ID]
+	})
+	return items
+}
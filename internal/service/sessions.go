@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"ember/internal/api"
+)
+
+func (s *MediaService) GetSessions() ([]SessionInfo, error) {
+	sessions, err := s.client.GetSessions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	result := make([]SessionInfo, 0, len(sessions))
+	for _, sess := range sessions {
+		if sess.UserID != s.client.UserID {
+			continue
+		}
+		info := SessionInfo{
+			ID:       sess.ID,
+			DeviceID: sess.DeviceID,
+			Device:   sess.DeviceName,
+			Client:   sess.Client,
+			Current:  sess.IsCurrentDevice(),
+		}
+		if sess.NowPlayingItem != nil {
+			info.NowPlaying = MediaTitle(s.convertItem(*sess.NowPlayingItem))
+		}
+		if sess.PlayState != nil {
+			info.PositionSec = sess.PlayState.PositionTicks / 10_000_000
+			info.Paused = sess.PlayState.IsPaused
+			info.PlayMethod = sess.PlayState.PlayMethod
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func (s *MediaService) StopSession(sessionID string) error {
+	if err := s.client.StopSession(sessionID); err != nil {
+		return fmt.Errorf("failed to stop playback: %w", err)
+	}
+	return nil
+}
+
+func (s *MediaService) StopAllSessions() (int, error) {
+	sessions, err := s.GetSessions()
+	if err != nil {
+		return 0, err
+	}
+
+	stopped := 0
+	for _, sess := range sessions {
+		if sess.Current || sess.NowPlaying == "" {
+			continue
+		}
+		if err := s.StopSession(sess.ID); err != nil {
+			return stopped, err
+		}
+		stopped++
+	}
+	return stopped, nil
+}
+
+func (s *MediaService) LogoutSession(sess SessionInfo) error {
+	if sess.Current {
+		return fmt.Errorf("cannot log out this device")
+	}
+	if err := s.client.DeleteDevice(sess.DeviceID); err != nil {
+		if api.IsStatus(err, http.StatusForbidden) || api.IsStatus(err, http.StatusUnauthorized) {
+			return fmt.Errorf("signing out other devices needs an administrator account")
+		}
+		return fmt.Errorf("failed to sign out device: %w", err)
+	}
+	return nil
+}
+
+func (s *MediaService) GetTranscodes() ([]TranscodeInfo, error) {
+	sessions, err := s.client.GetServerSessions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var result []TranscodeInfo
+	for _, sess := range sessions {
+		ti := sess.TranscodingInfo
+		if sess.NowPlayingItem == nil || ti == nil {
+			continue
+		}
+		info := TranscodeInfo{
+			User:       sess.UserName,
+			Device:     sess.DeviceName,
+			Client:     sess.Client,
+			NowPlaying: MediaTitle(s.convertItem(*sess.NowPlayingItem)),
+			VideoCodec: ti.VideoCodec,
+			AudioCodec: ti.AudioCodec,
+			Container:  ti.Container,
+			VideoCopy:  ti.IsVideoDirect,
+			AudioCopy:  ti.IsAudioDirect,
+			Bitrate:    ti.Bitrate,
+			Framerate:  ti.Framerate,
+			Progress:   ti.CompletionPercentage,
+			Throttled:  ti.IsThrottled,
+			Reasons:    ti.TranscodeReasons,
+			Mine:       sess.IsCurrentDevice(),
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func (s *MediaService) PreferDirectPlay(itemID string) error {
+	info, err := s.client.GetPlaybackInfo(itemID, "", 0, -1, false)
+	if err != nil {
+		return fmt.Errorf("failed to get playback info: %w", err)
+	}
+	for _, ms := range info.MediaSources {
+		if ms.SupportsDirectPlay || ms.SupportsDirectStream {
+			s.preferDirect.Store(itemID, true)
+			return nil
+		}
+	}
+	return fmt.Errorf("the server cannot direct play this item; its codecs or container need transcoding")
+}
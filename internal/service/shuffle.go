@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"ember/internal/api"
+)
+
+const shuffleSize = 25
+
+func (s *MediaService) BuildShufflePlayback(parentID, title string, unwatchedOnly bool) (*ContinuousPlaybackPlan, error) {
+	var items []api.MediaItem
+	if parentID != "" {
+		random, err := s.client.GetRandomItems(parentID, false, unwatchedOnly, shuffleSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get items: %w", err)
+		}
+		items = random
+	} else {
+		random, err := s.client.GetRandomItems("", true, unwatchedOnly, shuffleSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get favorites: %w", err)
+		}
+		items = random
+
+		favorites, _, err := s.client.GetFavorites(0, 100, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get favorites: %w", err)
+		}
+		for _, fav := range favorites {
+			if fav.Type != "Series" {
+				continue
+			}
+			if episodes, err := s.client.GetRandomItems(fav.ID, false, unwatchedOnly, shuffleSize); err == nil {
+				items = append(items, episodes...)
+			}
+		}
+		rand.Shuffle(len(items), func(i, j int) {
+			items[i], items[j] = items[j], items[i]
+		})
+	}
+
+	ids := make([]string, 0, shuffleSize)
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if seen[item.ID] || len(ids) == shuffleSize {
+			continue
+		}
+		seen[item.ID] = true
+		ids = append(ids, item.ID)
+	}
+	if len(ids) == 0 {
+		if unwatchedOnly {
+			return nil, fmt.Errorf("nothing unwatched to shuffle")
+		}
+		return nil, fmt.Errorf("nothing to shuffle")
+	}
+	return s.buildPlan("Shuffle: "+title, ids)
+}
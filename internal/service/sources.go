@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (s *MediaService) PreferredSource(item MediaItem) (MediaSource, bool) {
+	if len(item.MediaSources) == 0 {
+		return MediaSource{}, false
+	}
+	if sourceID, ok := s.store.GetSourcePref(item.ID); ok {
+		for _, ms := range item.MediaSources {
+			if ms.ID == sourceID {
+				return ms, true
+			}
+		}
+	}
+	return item.MediaSources[0], false
+}
+
+func (s *MediaService) NeedsSourceChoice(item MediaItem) bool {
+	if len(item.MediaSources) < 2 {
+		return false
+	}
+	_, remembered := s.PreferredSource(item)
+	return !remembered
+}
+
+func (s *MediaService) SetPreferredSource(itemID, sourceID string) {
+	s.store.SetSourcePref(itemID, sourceID)
+}
+
+func SourceLabel(ms MediaSource) string {
+	var parts []string
+	if name := strings.TrimSpace(ms.Name); name != "" {
+		parts = append(parts, name)
+	}
+	if height := fmt.Sprintf("%dp", ms.Height); ms.Height > 0 && !strings.Contains(ms.Name, height) {
+		parts = append(parts, height)
+	}
+	if ms.VideoCodec != "" {
+		parts = append(parts, strings.ToUpper(ms.VideoCodec))
+	}
+	if ms.VideoRange != "" && ms.VideoRange != "SDR" {
+		parts = append(parts, ms.VideoRange)
+	}
+	if ms.Container != "" {
+		parts = append(parts, ms.Container)
+	}
+	if ms.Bitrate > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f Mbps", float64(ms.Bitrate)/1_000_000))
+	}
+	if ms.Size > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f GB", float64(ms.Size)/(1<<30)))
+	}
+	if len(parts) == 0 {
+		return ms.ID
+	}
+	return strings.Join(parts, "  ")
+}
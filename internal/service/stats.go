@@ -0,0 +1,160 @@
+package service
+
+import (
+	"sort"
+
+	"ember/internal/storage"
+)
+
+const unknownGenre = "Unknown"
+
+type StatBucket struct {
+	Name       string `json:"name"`
+	WatchedSec int64  `json:"watchedSec"`
+	Plays      int    `json:"plays"`
+}
+
+type AbandonedTitle struct {
+	Name      string `json:"name"`
+	Started   int    `json:"started"`
+	Abandoned int    `json:"abandoned"`
+}
+
+type UsageStats struct {
+	WatchedSec int64            `json:"watchedSec"`
+	Plays      int              `json:"plays"`
+	Completed  int              `json:"completed"`
+	Genres     []StatBucket     `json:"genres"`
+	Abandoned  []AbandonedTitle `json:"abandoned"`
+}
+
+func (u UsageStats) CompletionRate() float64 {
+	if u.Plays == 0 {
+		return 0
+	}
+	return float64(u.Completed) / float64(u.Plays)
+}
+
+func (s *MediaService) Analytics() bool {
+	return s.store.GetSettings().Analytics
+}
+
+func (s *MediaService) SetAnalytics(enabled bool) {
+	s.store.UpdateSettings(func(settings *storage.Settings) {
+		settings.Analytics = enabled
+	})
+}
+
+// captureGenres fills in the genres of a starting session from the item
+// loaded for playback. Episodes usually carry none of their own; their
+// series is looked up in the background so neither the start nor the
+// stop report waits on it.
+func (s *MediaService) captureGenres(session *playbackSession) {
+	item, ok := s.cachedItem(session.itemID)
+	if !ok {
+		return
+	}
+	if len(item.Genres) > 0 {
+		session.genres.Store(&item.Genres)
+		return
+	}
+	if item.SeriesID == "" {
+		return
+	}
+	if series, ok := s.cachedItem(item.SeriesID); ok && len(series.Genres) > 0 {
+		session.genres.Store(&series.Genres)
+		return
+	}
+	go func() {
+		if series, err := s.client.GetItem(item.SeriesID); err == nil {
+			session.genres.Store(&series.Genres)
+		}
+	}()
+}
+
+func (s *MediaService) UsageStats(limit int) UsageStats {
+	var stats UsageStats
+	genres := make(map[string]*StatBucket)
+
+	type itemState struct {
+		title     string
+		completed bool
+	}
+	items := make(map[string]*itemState)
+
+	for _, r := range s.store.GetPlaybackRecords() {
+		stats.WatchedSec += r.WatchedSec
+		stats.Plays++
+		if r.Completed() {
+			stats.Completed++
+		}
+
+		names := r.Genres
+		if len(names) == 0 {
+			names = []string{unknownGenre}
+		}
+		for _, name := range names {
+			b := genres[name]
+			if b == nil {
+				b = &StatBucket{Name: name}
+				genres[name] = b
+			}
+			b.WatchedSec += r.WatchedSec
+			b.Plays++
+		}
+
+		st := items[r.ItemID]
+		if st == nil {
+			title := r.SeriesName
+			if title == "" {
+				title = r.Name
+			}
+			st = &itemState{title: title}
+			items[r.ItemID] = st
+		}
+		st.completed = st.completed || r.Completed()
+	}
+
+	for _, b := range genres {
+		stats.Genres = append(stats.Genres, *b)
+	}
+	sort.Slice(stats.Genres, func(i, j int) bool {
+		if stats.Genres[i].WatchedSec != stats.Genres[j].WatchedSec {
+			return stats.Genres[i].WatchedSec > stats.Genres[j].WatchedSec
+		}
+		return stats.Genres[i].Name < stats.Genres[j].Name
+	})
+
+	titles := make(map[string]*AbandonedTitle)
+	for _, st := range items {
+		if st.title == "" {
+			continue
+		}
+		t := titles[st.title]
+		if t == nil {
+			t = &AbandonedTitle{Name: st.title}
+			titles[st.title] = t
+		}
+		t.Started++
+		if !st.completed {
+			t.Abandoned++
+		}
+	}
+	for _, t := range titles {
+		if t.Abandoned > 0 {
+			stats.Abandoned = append(stats.Abandoned, *t)
+		}
+	}
+	sort.Slice(stats.Abandoned, func(i, j int) bool {
+		if stats.Abandoned[i].Abandoned != stats.Abandoned[j].Abandoned {
+			return stats.Abandoned[i].Abandoned > stats.Abandoned[j].Abandoned
+		}
+		return stats.Abandoned[i].Name < stats.Abandoned[j].Name
+	})
+
+	if limit > 0 {
+		stats.Genres = stats.Genres[:min(limit, len(stats.Genres))]
+		stats.Abandoned = stats.Abandoned[:min(limit, len(stats.Abandoned))]
+	}
+	return stats
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"ember/internal/api"
+	"ember/internal/logging"
+	"ember/internal/storage"
+)
+
+func shouldQueueReport(err error) bool {
+	if isUnreachable(err) {
+		return true
+	}
+	var apiErr *api.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+func (s *MediaService) queueStoppedReport(itemID, mediaSourceID, sessionID string, positionSec, durationSec int64) {
+	s.store.QueuePendingReport(storage.PendingReport{
+		ItemID:        itemID,
+		MediaSourceID: mediaSourceID,
+		SessionID:     sessionID,
+		PositionSec:   positionSec,
+		DurationSec:   durationSec,
+		QueuedAt:      time.Now().Format(time.RFC3339),
+	})
+	logging.Storage("Queued playback report", "item", itemID, "position", positionSec)
+}
+
+func (s *MediaService) PendingReportCount() int {
+	return len(s.store.GetPendingReports())
+}
+
+func (s *MediaService) SyncPendingReports() (int, error) {
+	synced := 0
+	for _, r := range s.store.GetPendingReports() {
+		err := s.client.ReportPlaybackStopped(r.ItemID, r.MediaSourceID, "", r.SessionID, r.PositionSec*10_000_000)
+		if err != nil && shouldQueueReport(err) {
+			return synced, err
+		}
+		s.store.RemovePendingReport(r.SessionID)
+		if err != nil {
+			logging.Storage("Dropped pending playback report", "item", r.ItemID, "error", err)
+			continue
+		}
+		finished := s.reachedPlayedThreshold(r.PositionSec, r.DurationSec)
+		if finished {
+			s.markFinished(r.ItemID)
+		}
+		s.creditCoveredEpisodes(r.ItemID, r.PositionSec, r.DurationSec, finished)
+		synced++
+	}
+	return synced, nil
+}
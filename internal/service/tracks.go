@@ -0,0 +1,31 @@
+package service
+
+import (
+	"ember/internal/logging"
+	"ember/internal/player"
+)
+
+func (s *MediaService) seriesTracks(seriesID string) *player.TrackChoice {
+	pref, ok := s.store.GetTrackPref(seriesID)
+	if !ok {
+		return nil
+	}
+	return &player.TrackChoice{
+		AudioLang:    pref.AudioLang,
+		SubtitleLang: pref.SubtitleLang,
+		SubtitlesOff: pref.SubtitlesOff,
+	}
+}
+
+func (s *MediaService) rememberTracks(seriesID string, choice player.TrackChoice) {
+	pref, _ := s.store.GetTrackPref(seriesID)
+	if choice.AudioLang != "" {
+		pref.AudioLang = choice.AudioLang
+	}
+	pref.SubtitlesOff = choice.SubtitlesOff
+	if choice.SubtitleLang != "" {
+		pref.SubtitleLang = choice.SubtitleLang
+	}
+	s.store.SetTrackPref(seriesID, pref)
+	logging.Player("Remembered track choice", "series", seriesID, "audio", pref.AudioLang, "subtitle", pref.SubtitleLang, "off", pref.SubtitlesOff)
+}
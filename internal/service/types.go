@@ -2,8 +2,10 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"ember/internal/api"
+	"ember/internal/player"
 )
 
 type MediaItem struct {
@@ -17,8 +19,13 @@ type MediaItem struct {
 	SeasonName   string        `json:"seasonName,omitempty"`
 	ParentID     string        `json:"parentId,omitempty"`
 	IndexNumber  int           `json:"indexNumber,omitempty"`
+	IndexEnd     int           `json:"indexEnd,omitempty"`
+	SeasonNumber int           `json:"seasonNumber,omitempty"`
+	ChildCount   int           `json:"childCount,omitempty"`
+	PremiereDate string        `json:"premiereDate,omitempty"`
 	Overview     string        `json:"overview,omitempty"`
 	RunTimeTicks int64         `json:"runTimeTicks,omitempty"`
+	Rating       float64       `json:"rating,omitempty"`
 	ImageURL     string        `json:"imageUrl,omitempty"`
 	ImageURLs    []string      `json:"imageUrls,omitempty"`
 	ImageURLHigh string        `json:"imageUrlHigh,omitempty"`
@@ -27,6 +34,25 @@ type MediaItem struct {
 	MediaSources []MediaSource `json:"mediaSources,omitempty"`
 	Playable     bool          `json:"playable"`
 	Browsable    bool          `json:"browsable"`
+	MatchType    string        `json:"matchType,omitempty"`
+	Download     string        `json:"download,omitempty"`
+	Chapters     []Chapter     `json:"chapters,omitempty"`
+	Genres       []string      `json:"genres,omitempty"`
+	Status       string        `json:"status,omitempty"`
+	Album        string        `json:"album,omitempty"`
+	AlbumID      string        `json:"albumId,omitempty"`
+	Artist       string        `json:"artist,omitempty"`
+	NextAirDate  string        `json:"nextAirDate,omitempty"`
+	NextEpisode  string        `json:"nextEpisode,omitempty"`
+	Channel      string        `json:"channel,omitempty"`
+	NowShowing   string        `json:"nowShowing,omitempty"`
+	Library      string        `json:"library,omitempty"`
+}
+
+type Chapter struct {
+	Name       string `json:"name"`
+	StartSec   int64  `json:"startSec"`
+	MarkerType string `json:"markerType,omitempty"`
 }
 
 type UserData struct {
@@ -35,13 +61,37 @@ type UserData struct {
 	IsFavorite            bool   `json:"isFavorite"`
 	LastPlayedDate        string `json:"lastPlayedDate,omitempty"`
 	PlaybackPositionPct   int    `json:"playbackPositionPct,omitempty"`
+	PlayCount             int    `json:"playCount,omitempty"`
+	UnplayedItemCount     int    `json:"unplayedItemCount,omitempty"`
+	Likes                 *bool  `json:"likes,omitempty"`
+}
+
+type PlaybackHistory struct {
+	LastPlayed  time.Time `json:"lastPlayed,omitempty"`
+	PositionSec int64     `json:"positionSec,omitempty"`
+	PlayCount   int       `json:"playCount,omitempty"`
 }
 
 type MediaSource struct {
-	ID        string         `json:"id"`
-	Container string         `json:"container"`
-	Protocol  string         `json:"protocol,omitempty"`
-	Subtitles []SubtitleInfo `json:"subtitles,omitempty"`
+	ID         string         `json:"id"`
+	Name       string         `json:"name,omitempty"`
+	Container  string         `json:"container"`
+	Protocol   string         `json:"protocol,omitempty"`
+	Size       int64          `json:"size,omitempty"`
+	Bitrate    int64          `json:"bitrate,omitempty"`
+	Width      int            `json:"width,omitempty"`
+	Height     int            `json:"height,omitempty"`
+	VideoCodec string         `json:"videoCodec,omitempty"`
+	VideoRange string         `json:"videoRange,omitempty"`
+	Subtitles  []SubtitleInfo `json:"subtitles,omitempty"`
+	Audio      []AudioTrack   `json:"audio,omitempty"`
+}
+
+type AudioTrack struct {
+	Index    int    `json:"index"`
+	Language string `json:"language,omitempty"`
+	Codec    string `json:"codec,omitempty"`
+	Channels int    `json:"channels,omitempty"`
 }
 
 type MediaDetail struct {
@@ -67,9 +117,11 @@ type SubtitleInfo struct {
 type MediaList struct {
 	Items    []MediaItem `json:"items"`
 	Total    int         `json:"total"`
+	Hidden   int         `json:"hidden,omitempty"`
 	Page     int         `json:"page"`
 	PageSize int         `json:"pageSize"`
 	HasMore  bool        `json:"hasMore"`
+	Next     string      `json:"next,omitempty"`
 }
 
 type StreamInfo struct {
@@ -79,6 +131,7 @@ type StreamInfo struct {
 	SeriesName    string         `json:"seriesName,omitempty"`
 	Type          string         `json:"type"`
 	StreamURL     string         `json:"streamUrl"`
+	PlayMethod    string         `json:"playMethod,omitempty"`
 	PosterURL     string         `json:"posterUrl,omitempty"`
 	Container     string         `json:"container,omitempty"`
 	Duration      int64          `json:"duration,omitempty"`
@@ -90,11 +143,56 @@ type StreamInfo struct {
 }
 
 type ContinuousPlaybackPlan struct {
-	Title       string      `json:"title"`
-	StartIndex  int         `json:"startIndex"`
-	URLs        []string    `json:"urls"`
-	CurrentItem MediaItem   `json:"currentItem"`
-	StreamInfo  *StreamInfo `json:"streamInfo,omitempty"`
+	Title       string            `json:"title"`
+	StartIndex  int               `json:"startIndex"`
+	URLs        []string          `json:"urls"`
+	Items       []MediaItem       `json:"items"`
+	Metadata    []player.Metadata `json:"-"`
+	CurrentItem MediaItem         `json:"currentItem"`
+	StreamInfo  *StreamInfo       `json:"streamInfo,omitempty"`
+}
+
+type Program struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Episode string    `json:"episode,omitempty"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+type GuideRow struct {
+	Channel  MediaItem `json:"channel"`
+	Programs []Program `json:"programs"`
+}
+
+type SessionInfo struct {
+	ID          string `json:"id"`
+	DeviceID    string `json:"deviceId"`
+	Device      string `json:"device"`
+	Client      string `json:"client"`
+	NowPlaying  string `json:"nowPlaying,omitempty"`
+	PositionSec int64  `json:"positionSec,omitempty"`
+	Paused      bool   `json:"paused,omitempty"`
+	PlayMethod  string `json:"playMethod,omitempty"`
+	Current     bool   `json:"current,omitempty"`
+}
+
+type TranscodeInfo struct {
+	User       string   `json:"user"`
+	Device     string   `json:"device"`
+	Client     string   `json:"client"`
+	NowPlaying string   `json:"nowPlaying"`
+	VideoCodec string   `json:"videoCodec,omitempty"`
+	AudioCodec string   `json:"audioCodec,omitempty"`
+	Container  string   `json:"container,omitempty"`
+	VideoCopy  bool     `json:"videoCopy,omitempty"`
+	AudioCopy  bool     `json:"audioCopy,omitempty"`
+	Bitrate    int      `json:"bitrate,omitempty"`
+	Framerate  float64  `json:"framerate,omitempty"`
+	Progress   float64  `json:"progress,omitempty"`
+	Throttled  bool     `json:"throttled,omitempty"`
+	Reasons    []string `json:"reasons,omitempty"`
+	Mine       bool     `json:"mine,omitempty"`
 }
 
 type ServerInfo struct {
@@ -105,6 +203,8 @@ type ServerInfo struct {
 	IsActive bool   `json:"isActive"`
 	Prefix   string `json:"prefix,omitempty"`
 	Latency  int64  `json:"latency,omitempty"`
+
+	AuthFailed bool `json:"authFailed,omitempty"`
 }
 
 type ServerStatus struct {
@@ -112,6 +212,7 @@ type ServerStatus struct {
 	Server       *ServerInfo `json:"server,omitempty"`
 	Latency      int64       `json:"latency,omitempty"`
 	MpvAvailable bool        `json:"mpvAvailable"`
+	Maintenance  Maintenance `json:"maintenance,omitempty"`
 	Error        string      `json:"error,omitempty"`
 }
 
@@ -132,6 +233,59 @@ type SearchQuery struct {
 	Year         int    `json:"year,omitempty"`
 }
 
+type ItemFilter struct {
+	Sort       string `json:"sort,omitempty"`
+	Descending bool   `json:"descending,omitempty"`
+	Unwatched  bool   `json:"unwatched,omitempty"`
+	Genre      string `json:"genre,omitempty"`
+	MinYear    int    `json:"minYear,omitempty"`
+	MaxYear    int    `json:"maxYear,omitempty"`
+}
+
+var ItemSorts = []string{"name", "added", "premiere", "rating"}
+
+func (f ItemFilter) IsZero() bool {
+	return f == ItemFilter{}
+}
+
+func (f ItemFilter) query() api.ItemQuery {
+	var q api.ItemQuery
+	switch f.Sort {
+	case "added":
+		q.SortBy = "DateCreated,SortName"
+	case "premiere":
+		q.SortBy = "PremiereDate,SortName"
+	case "rating":
+		q.SortBy = "CommunityRating,SortName"
+	default:
+		q.SortBy = "SortName"
+	}
+	q.SortOrder = "Ascending"
+	if f.Descending {
+		q.SortOrder = "Descending"
+	}
+	if f.Unwatched {
+		q.Filters = append(q.Filters, "IsUnplayed")
+	}
+	if f.Genre != "" {
+		q.Genres = []string{f.Genre}
+	}
+
+	minYear, maxYear := f.MinYear, f.MaxYear
+	if minYear > 0 && maxYear == 0 {
+		maxYear = time.Now().Year()
+	}
+	if maxYear > 0 && minYear == 0 {
+		minYear = maxYear
+	}
+	if minYear > 0 && maxYear >= minYear && maxYear-minYear <= 200 {
+		for year := minYear; year <= maxYear; year++ {
+			q.Years = append(q.Years, year)
+		}
+	}
+	return q
+}
+
 type Pagination struct {
 	Page     int `json:"page"`
 	PageSize int `json:"pageSize"`
@@ -141,6 +295,14 @@ type FavoriteResult struct {
 	IsFavorite bool `json:"isFavorite"`
 }
 
+type RatingResult struct {
+	Likes *bool `json:"likes"`
+}
+
+type PlayedResult struct {
+	Played bool `json:"played"`
+}
+
 type PlayRequest struct {
 	ItemID string `json:"itemId"`
 }
@@ -174,9 +336,15 @@ func convertAPIItem(item api.MediaItem, imageBaseURL, token string) MediaItem {
 	imageURLHigh := firstImageURL(buildImageCandidateURLs(item, imageBaseURL, token, 800))
 	backdropURL := buildBackdropURL(item, imageBaseURL, token)
 
-	playable := item.Type == "Movie" || item.Type == "Episode" || item.Type == "Video"
+	playable := item.Type == "Movie" || item.Type == "Episode" || item.Type == "Video" || item.Type == "Audio" || item.Type == "TvChannel"
 	browsable := item.Type == "Series" || item.Type == "Season" ||
-		item.Type == "CollectionFolder" || item.Type == "Folder" || item.Type == "BoxSet"
+		item.Type == "CollectionFolder" || item.Type == "Folder" || item.Type == "BoxSet" ||
+		item.Type == "Studio" || item.Type == "Genre" || item.Type == "MusicAlbum" || item.Type == "Playlist"
+
+	var nowShowing string
+	if item.CurrentProgram != nil {
+		nowShowing = item.CurrentProgram.Name
+	}
 
 	var userData *UserData
 	if item.UserData != nil {
@@ -189,14 +357,34 @@ func convertAPIItem(item api.MediaItem, imageBaseURL, token string) MediaItem {
 			Played:                item.UserData.Played,
 			IsFavorite:            item.UserData.IsFavorite,
 			LastPlayedDate:        item.UserData.LastPlayedDate,
+			PlayCount:             item.UserData.PlayCount,
+			UnplayedItemCount:     item.UserData.UnplayedItemCount,
+			Likes:                 item.UserData.Likes,
 			PlaybackPositionPct:   pct,
 		}
 	}
 
+	var chapters []Chapter
+	for _, ch := range item.Chapters {
+		chapters = append(chapters, Chapter{
+			Name:       ch.Name,
+			StartSec:   ch.StartPositionTicks / 10000000,
+			MarkerType: ch.MarkerType,
+		})
+	}
+
 	var mediaSources []MediaSource
 	for _, ms := range item.MediaSources {
 		var subtitles []SubtitleInfo
+		var audio []AudioTrack
+		var video api.MediaStream
 		for _, stream := range ms.MediaStreams {
+			if stream.Type == "Video" && video.Codec == "" {
+				video = stream
+			}
+			if stream.Type == "Audio" {
+				audio = append(audio, AudioTrack{Index: stream.Index, Language: stream.Language, Codec: stream.Codec, Channels: stream.Channels})
+			}
 			if stream.Type != "Subtitle" {
 				continue
 			}
@@ -211,10 +399,18 @@ func convertAPIItem(item api.MediaItem, imageBaseURL, token string) MediaItem {
 		}
 
 		mediaSources = append(mediaSources, MediaSource{
-			ID:        ms.ID,
-			Container: ms.Container,
-			Protocol:  ms.Protocol,
-			Subtitles: subtitles,
+			ID:         ms.ID,
+			Name:       ms.Name,
+			Container:  ms.Container,
+			Protocol:   ms.Protocol,
+			Size:       ms.Size,
+			Bitrate:    ms.Bitrate,
+			Width:      video.Width,
+			Height:     video.Height,
+			VideoCodec: video.Codec,
+			VideoRange: video.VideoRange,
+			Subtitles:  subtitles,
+			Audio:      audio,
 		})
 	}
 
@@ -229,8 +425,13 @@ func convertAPIItem(item api.MediaItem, imageBaseURL, token string) MediaItem {
 		SeasonName:   item.SeasonName,
 		ParentID:     item.ParentID,
 		IndexNumber:  item.IndexNumber,
+		IndexEnd:     item.IndexNumberEnd,
+		SeasonNumber: item.ParentIndexNumber,
+		ChildCount:   item.ChildCount + item.MovieCount + item.SeriesCount,
+		PremiereDate: item.PremiereDate,
 		Overview:     item.Overview,
 		RunTimeTicks: item.RunTimeTicks,
+		Rating:       item.CommunityRating,
 		ImageURL:     imageURL,
 		ImageURLs:    imageURLs,
 		ImageURLHigh: imageURLHigh,
@@ -239,6 +440,14 @@ func convertAPIItem(item api.MediaItem, imageBaseURL, token string) MediaItem {
 		MediaSources: mediaSources,
 		Playable:     playable,
 		Browsable:    browsable,
+		Chapters:     chapters,
+		Genres:       item.Genres,
+		Status:       item.Status,
+		Album:        item.Album,
+		AlbumID:      item.AlbumID,
+		Artist:       item.AlbumArtist,
+		Channel:      item.Number,
+		NowShowing:   nowShowing,
 	}
 }
 
@@ -247,37 +456,43 @@ func buildImageCandidateURLs(item api.MediaItem, imageBaseURL, token string, wid
 	isEpisode := item.Type == "Episode"
 
 	if item.ImageTags.Primary != "" {
-		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Primary", width, token))
+		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Primary", width, item.ImageTags.Primary, token))
 	}
 	if item.ImageTags.Thumb != "" {
-		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Thumb", width, token))
+		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Thumb", width, item.ImageTags.Thumb, token))
+	}
+	if item.Type == "Studio" {
+		if item.ImageTags.Logo != "" {
+			urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Logo", width, item.ImageTags.Logo, token))
+		}
+		return urls
 	}
 	if isEpisode {
 		if item.ParentThumbItemID != "" && item.ParentThumbImageTag != "" {
-			urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ParentThumbItemID, "Thumb", width, token))
+			urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ParentThumbItemID, "Thumb", width, item.ParentThumbImageTag, token))
 		}
 		if len(item.BackdropImageTags) > 0 {
-			urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Backdrop", width, token))
+			urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Backdrop", width, item.BackdropImageTags[0], token))
 		}
 		if item.ParentBackdropItemID != "" && len(item.ParentBackdropTags) > 0 {
-			urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ParentBackdropItemID, "Backdrop", width, token))
+			urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ParentBackdropItemID, "Backdrop", width, item.ParentBackdropTags[0], token))
 		}
 		return urls
 	}
 	if item.SeriesPrimaryImageTag != "" && item.SeriesID != "" {
-		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.SeriesID, "Primary", width, token))
+		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.SeriesID, "Primary", width, item.SeriesPrimaryImageTag, token))
 	}
 	if item.SeasonID != "" {
-		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.SeasonID, "Primary", width, token))
+		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.SeasonID, "Primary", width, "", token))
 	}
 	if item.ParentID != "" {
-		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ParentID, "Primary", width, token))
+		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ParentID, "Primary", width, "", token))
 	}
 	if len(item.BackdropImageTags) > 0 {
-		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Backdrop", width, token))
+		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.ID, "Backdrop", width, item.BackdropImageTags[0], token))
 	}
 	if item.SeriesID != "" {
-		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.SeriesID, "Backdrop", width, token))
+		urls = appendUniqueImageURL(urls, buildImageURL(imageBaseURL, item.SeriesID, "Backdrop", width, "", token))
 	}
 
 	return urls
@@ -285,20 +500,25 @@ func buildImageCandidateURLs(item api.MediaItem, imageBaseURL, token string, wid
 
 func buildBackdropURL(item api.MediaItem, imageBaseURL, token string) string {
 	if len(item.BackdropImageTags) > 0 {
-		return buildImageURL(imageBaseURL, item.ID, "Backdrop", 800, token)
+		return buildImageURL(imageBaseURL, item.ID, "Backdrop", 800, item.BackdropImageTags[0], token)
 	}
 	if item.Type == "Episode" && item.ParentBackdropItemID != "" && len(item.ParentBackdropTags) > 0 {
-		return buildImageURL(imageBaseURL, item.ParentBackdropItemID, "Backdrop", 800, token)
+		return buildImageURL(imageBaseURL, item.ParentBackdropItemID, "Backdrop", 800, item.ParentBackdropTags[0], token)
 	}
 	if item.SeriesID != "" {
-		return buildImageURL(imageBaseURL, item.SeriesID, "Backdrop", 800, token)
+		return buildImageURL(imageBaseURL, item.SeriesID, "Backdrop", 800, "", token)
 	}
 	return ""
 }
 
-func buildImageURL(imageBaseURL, itemID, imageType string, width int, token string) string {
-	return fmt.Sprintf("%s/emby/Items/%s/Images/%s?maxWidth=%d&api_key=%s",
-		imageBaseURL, itemID, imageType, width, token)
+// buildImageURL adds the image tag when it is known; it changes whenever
+// the picture does, so cached copies of the old one are never reused.
+func buildImageURL(imageBaseURL, itemID, imageType string, width int, tag, token string) string {
+	url := fmt.Sprintf("%s/emby/Items/%s/Images/%s?maxWidth=%d", imageBaseURL, itemID, imageType, width)
+	if tag != "" {
+		url += "&tag=" + tag
+	}
+	return url + "&api_key=" + token
 }
 
 func appendUniqueImageURL(urls []string, url string) []string {
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+
+	"ember/internal/api"
+)
+
+func (s *MediaService) episodesOf(item MediaItem) ([]api.MediaItem, error) {
+	var seriesID, seasonID string
+	switch item.Type {
+	case "Series":
+		seriesID = item.ID
+	case "Season":
+		seriesID, seasonID = item.SeriesID, item.ID
+		if seriesID == "" {
+			seriesID = item.ParentID
+		}
+	default:
+		return nil, fmt.Errorf("only seasons and series have episodes")
+	}
+
+	episodes, err := s.client.GetEpisodes(seriesID, seasonID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episodes: %w", err)
+	}
+	return episodes, nil
+}
+
+func (s *MediaService) EpisodesToMark(item MediaItem, played bool) ([]MediaItem, error) {
+	episodes, err := s.episodesOf(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []MediaItem
+	for _, ep := range episodes {
+		if ep.UserData != nil && ep.UserData.Played == played {
+			continue
+		}
+		pending = append(pending, s.convertItem(ep))
+	}
+	return pending, nil
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"ember/internal/logging"
+)
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		backup := path + ".bak"
+		_ = os.Remove(backup)
+		if err := os.Link(path, backup); err != nil {
+			logging.Storage("Failed to keep backup", "path", backup, "error", err)
+		}
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func readJSONWithBackup[T any](path string) (T, error) {
+	var v T
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err = json.Unmarshal(data, &v); err == nil {
+			return v, nil
+		}
+	}
+	if os.IsNotExist(err) {
+		return v, err
+	}
+
+	var restored T
+	backup, bakErr := os.ReadFile(path + ".bak")
+	if bakErr != nil {
+		return v, err
+	}
+	if bakErr := json.Unmarshal(backup, &restored); bakErr != nil {
+		return v, err
+	}
+	logging.Storage("Restored from backup", "path", path, "error", err)
+	return restored, nil
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+const (
+	// auditMaxBytes is the size at which audit.jsonl is rotated to
+	// audit.jsonl.1, replacing the previous rotation, so the trail keeps
+	// at most twice this much.
+	auditMaxBytes = 1 << 20
+
+	// auditTailBytes is how much of the end of each file ReadAudit reads.
+	auditTailBytes = 256 << 10
+)
+
+type AuditEntry struct {
+	Time    string `json:"time"`
+	User    string `json:"user"`
+	Server  string `json:"server,omitempty"`
+	Action  string `json:"action"`
+	Subject string `json:"subject,omitempty"`
+}
+
+var auditMu sync.Mutex
+
+// AuditPath is audit.jsonl in the active backend's directory, so it
+// follows data_dir.
+func (s *Store) AuditPath() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return filepath.Join(s.backend.Dir(), "audit.jsonl")
+}
+
+func (s *Store) AppendAudit(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	path := s.AuditPath()
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if info, err := os.Stat(path); err == nil && info.Size() >= auditMaxBytes {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// ReadAudit returns up to limit of the most recent entries, newest first.
+// Only the tail of the log is read, falling back to the rotated file when
+// the current one is short.
+func (s *Store) ReadAudit(limit int) ([]AuditEntry, error) {
+	path := s.AuditPath()
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	entries, err := readAuditTail(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) < limit {
+		older, err := readAuditTail(path + ".1")
+		if err != nil {
+			return nil, err
+		}
+		entries = append(older, entries...)
+	}
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+func readAuditTail(path string) ([]AuditEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	offset := max(info.Size()-auditTailBytes, 0)
+	data, err := io.ReadAll(io.NewSectionReader(f, offset, info.Size()-offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if offset > 0 {
+		// The read most likely starts mid-line; drop that partial line.
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+
+	var entries []AuditEntry
+	for line := range bytes.Lines(data) {
+		var entry AuditEntry
+		if json.Unmarshal(line, &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+// Storage backends selectable with the storage_backend setting.
+const (
+	BackendJSON   = "json"
+	BackendSQLite = "sqlite"
+)
+
+var Backends = []string{BackendJSON, BackendSQLite}
+
+type Backend interface {
+	Name() string
+	// Dir is where the backend keeps its files; other per-install data
+	// such as the audit trail goes next to them.
+	Dir() string
+	LoadData(key string) (ServerData, error)
+	SaveData(key string, data ServerData) error
+}
+
+type fileBackend struct {
+	dir string
+}
+
+func NewFileBackend(dir string) Backend {
+	return &fileBackend{dir: dir}
+}
+
+func (b *fileBackend) Name() string {
+	return "file:" + b.dir
+}
+
+func (b *fileBackend) Dir() string {
+	return b.dir
+}
+
+func (b *fileBackend) path(key string) string {
+	return filepath.Join(b.dir, "data_"+key+".json")
+}
+
+func (b *fileBackend) LoadData(key string) (ServerData, error) {
+	return readJSONWithBackup[ServerData](b.path(key))
+}
+
+func (b *fileBackend) SaveData(key string, data ServerData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(b.path(key), raw, 0644)
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
+
+func (s *Store) listingPath(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.validServerIndex(s.config.ActiveServer) {
+		return ""
+	}
+	prefix := s.config.Servers[s.config.ActiveServer].Prefix()
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(configDir, "cache", prefix, hex.EncodeToString(sum[:])+".json")
+}
+
+func (s *Store) SetCachedListing(key string, data []byte) error {
+	path := s.listingPath(key)
+	if path == "" {
+		return nil
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (s *Store) GetCachedListing(key string) ([]byte, bool) {
+	path := s.listingPath(key)
+	if path == "" {
+		return nil, false
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
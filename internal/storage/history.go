@@ -0,0 +1,43 @@
+package storage
+
+const maxPlaybackRecords = 1000
+
+type PlaybackRecord struct {
+	ItemID      string `json:"item_id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	SeriesName  string `json:"series_name,omitempty"`
+	Server      string `json:"server"`
+	StartedAt   string `json:"started_at"`
+	EndedAt     string `json:"ended_at"`
+	StartSec    int64  `json:"start_sec"`
+	EndSec      int64  `json:"end_sec"`
+	WatchedSec  int64  `json:"watched_sec"`
+	DurationSec int64  `json:"duration_sec,omitempty"`
+
+	Genres []string `json:"genres,omitempty"`
+}
+
+func (r PlaybackRecord) Completed() bool {
+	return r.DurationSec > 0 && r.EndSec*10 >= r.DurationSec*9
+}
+
+func (s *Store) AddPlaybackRecord(r PlaybackRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Playbacks = append(s.data.Playbacks, r)
+	if over := len(s.data.Playbacks) - maxPlaybackRecords; over > 0 {
+		s.data.Playbacks = append([]PlaybackRecord(nil), s.data.Playbacks[over:]...)
+	}
+	_ = s.saveData()
+}
+
+func (s *Store) GetPlaybackRecords() []PlaybackRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := make([]PlaybackRecord, len(s.data.Playbacks))
+	for i, r := range s.data.Playbacks {
+		records[len(records)-1-i] = r
+	}
+	return records
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/zalando/go-keyring"
+
+	"ember/internal/logging"
+)
+
+const keyringService = "ember"
+
+type serverSecret struct {
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+func keyringAccount(srv Server) string {
+	return srv.Username + "@" + srv.URL
+}
+
+func (s *Store) keyringEnabled() bool {
+	return !s.config.Settings.DisableKeyring
+}
+
+func (s *Store) loadSecrets() bool {
+	migrate := false
+	for i := range s.config.Servers {
+		srv := &s.config.Servers[i]
+		if !srv.Keyring {
+			if srv.Password != "" || srv.Token != "" {
+				migrate = true
+			}
+			continue
+		}
+
+		data, err := keyring.Get(keyringService, keyringAccount(*srv))
+		if err != nil {
+			logging.Storage("Failed to read credentials from keyring", "server", srv.Name, "error", err)
+			if !errors.Is(err, keyring.ErrNotFound) {
+				s.unreadSecrets[keyringAccount(*srv)] = true
+			}
+			continue
+		}
+		var secret serverSecret
+		if err := json.Unmarshal([]byte(data), &secret); err != nil {
+			continue
+		}
+		srv.Password = secret.Password
+		srv.Token = secret.Token
+		s.savedSecrets[keyringAccount(*srv)] = data
+	}
+	return migrate && s.keyringEnabled()
+}
+
+func (s *Store) persistableServers() []Server {
+	servers := make([]Server, len(s.config.Servers))
+	copy(servers, s.config.Servers)
+	if !s.keyringEnabled() {
+		for i := range servers {
+			servers[i].Keyring = false
+		}
+		return servers
+	}
+
+	for i := range servers {
+		srv := &servers[i]
+		account := keyringAccount(*srv)
+		if srv.Password == "" && srv.Token == "" {
+			// A secret the keyring refused to hand out (locked, or no
+			// D-Bus session) is still there; only an empty one is gone.
+			srv.Keyring = s.unreadSecrets[account]
+			continue
+		}
+		if err := s.storeSecret(*srv); err != nil {
+			srv.Keyring = false
+			continue
+		}
+		delete(s.unreadSecrets, account)
+		srv.Password = ""
+		srv.Token = ""
+		srv.Keyring = true
+	}
+	return servers
+}
+
+func (s *Store) storeSecret(srv Server) error {
+	data, err := json.Marshal(serverSecret{Password: srv.Password, Token: srv.Token})
+	if err != nil {
+		return err
+	}
+	account := keyringAccount(srv)
+	if s.savedSecrets[account] == string(data) {
+		return nil
+	}
+	if s.keyringErr != nil {
+		return s.keyringErr
+	}
+	if err := keyring.Set(keyringService, account, string(data)); err != nil {
+		logging.Storage("Keyring unavailable, storing credentials in config", "error", err)
+		s.keyringErr = err
+		return err
+	}
+	s.savedSecrets[account] = string(data)
+	return nil
+}
+
+func (s *Store) deleteSecret(srv Server) {
+	if !s.keyringEnabled() {
+		return
+	}
+	account := keyringAccount(srv)
+	for _, other := range s.config.Servers {
+		if keyringAccount(other) == account {
+			return
+		}
+	}
+	delete(s.savedSecrets, account)
+	delete(s.unreadSecrets, account)
+	if err := keyring.Delete(keyringService, account); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		logging.Storage("Failed to delete credentials from keyring", "server", srv.Name, "error", err)
+	}
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+
+	_ "modernc.org/sqlite"
+)
+
+const sqliteFile = "ember.db"
+
+type sqliteBackend struct {
+	path string
+	db   *sql.DB
+}
+
+// NewSQLiteBackend opens (or creates) ember.db in dir. Each server's data
+// is one JSON row, so a save is a single transaction instead of a file
+// rewrite and concurrent readers never see a half-written document.
+func NewSQLiteBackend(dir string) (Backend, error) {
+	path := filepath.Join(dir, sqliteFile)
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS server_data (
+		key  TEXT PRIMARY KEY,
+		data TEXT NOT NULL
+	)`); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteBackend{path: path, db: db}, nil
+}
+
+func (b *sqliteBackend) Name() string {
+	return "sqlite:" + b.path
+}
+
+func (b *sqliteBackend) Dir() string {
+	return filepath.Dir(b.path)
+}
+
+func (b *sqliteBackend) LoadData(key string) (ServerData, error) {
+	var data ServerData
+	var raw string
+	err := b.db.QueryRow(`SELECT data FROM server_data WHERE key = ?`, key).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return data, nil
+	}
+	if err != nil {
+		return data, err
+	}
+	err = json.Unmarshal([]byte(raw), &data)
+	return data, err
+}
+
+func (b *sqliteBackend) SaveData(key string, data ServerData) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = b.db.Exec(`INSERT INTO server_data (key, data) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET data = excluded.data`, key, string(raw))
+	return err
+}
+
+func (b *sqliteBackend) Close() error {
+	return b.db.Close()
+}
@@ -2,20 +2,45 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
+
+	"ember/internal/logging"
 )
 
 type Server struct {
-	Name     string `json:"name"`
-	URL      string `json:"url"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	UserID   string `json:"user_id,omitempty"`
-	Token    string `json:"token,omitempty"`
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	UserID     string `json:"user_id,omitempty"`
+	Token      string `json:"token,omitempty"`
+	MaxBitrate int    `json:"max_bitrate,omitempty"`
+	Keyring    bool   `json:"keyring,omitempty"`
+	AuthFailed bool   `json:"auth_failed,omitempty"`
+
+	AccessWindows []AccessWindow `json:"access_windows,omitempty"`
+}
+
+type AccessWindow struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days,omitempty"`
+}
+
+func (s Server) clone() Server {
+	s.AccessWindows = append([]AccessWindow(nil), s.AccessWindows...)
+	for i := range s.AccessWindows {
+		s.AccessWindows[i].Days = append([]string(nil), s.AccessWindows[i].Days...)
+	}
+	return s
 }
 
 func (s *Server) Prefix() string {
@@ -54,14 +79,90 @@ type MediaDetail struct {
 	UpdatedAt   string         `json:"updated_at,omitempty"`
 }
 
+type Settings struct {
+	ReleaseWindowDays int             `json:"release_window_days,omitempty"`
+	HideWatched       bool            `json:"hide_watched,omitempty"`
+	HideWatchedViews  map[string]bool `json:"hide_watched_views,omitempty"`
+
+	DisabledLogCategories []string `json:"disabled_log_categories,omitempty"`
+	ImageRenderer         string   `json:"image_renderer,omitempty"`
+	ImageQuality          string   `json:"image_quality,omitempty"`
+	DownloadDir           string   `json:"download_dir,omitempty"`
+	DownloadTemplate      string   `json:"download_template,omitempty"`
+	DisableKeyring        bool     `json:"disable_keyring,omitempty"`
+
+	KioskPINHash     string `json:"kiosk_pin_hash,omitempty"`
+	KioskLibraryID   string `json:"kiosk_library_id,omitempty"`
+	KioskLibraryName string `json:"kiosk_library_name,omitempty"`
+
+	Analytics bool `json:"analytics,omitempty"`
+
+	MPVProfile string   `json:"mpv_profile,omitempty"`
+	MPVArgs    []string `json:"mpv_args,omitempty"`
+
+	PrewarmImages bool `json:"prewarm_images,omitempty"`
+
+	DataDir        string `json:"data_dir,omitempty"`
+	StorageBackend string `json:"storage_backend,omitempty"`
+
+	PlayedThreshold int `json:"played_threshold,omitempty"`
+	ResumeRewindSec int `json:"resume_rewind_sec,omitempty"`
+
+	SubtitleLangs  []string `json:"subtitle_langs,omitempty"`
+	AudioLangs     []string `json:"audio_langs,omitempty"`
+	ForcedSubsOnly bool     `json:"forced_subs_only,omitempty"`
+}
+
 type ServerConfig struct {
 	Servers      []Server `json:"servers,omitempty"`
 	ActiveServer int      `json:"active_server"`
+	Settings     Settings `json:"settings"`
+}
+
+type Download struct {
+	ItemID       string `json:"item_id"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	SeriesName   string `json:"series_name,omitempty"`
+	Path         string `json:"path"`
+	Size         int64  `json:"size,omitempty"`
+	ExpectedSize int64  `json:"expected_size,omitempty"`
+	Complete     bool   `json:"complete"`
+	Verified     bool   `json:"verified,omitempty"`
+	DownloadedAt string `json:"downloaded_at,omitempty"`
+}
+
+type PendingReport struct {
+	ItemID        string `json:"item_id"`
+	MediaSourceID string `json:"media_source_id,omitempty"`
+	SessionID     string `json:"session_id"`
+	PositionSec   int64  `json:"position_sec"`
+	DurationSec   int64  `json:"duration_sec,omitempty"`
+	QueuedAt      string `json:"queued_at"`
+}
+
+type QueueEntry struct {
+	ItemID     string `json:"item_id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	SeriesName string `json:"series_name,omitempty"`
 }
 
 type ServerData struct {
-	Items        map[string]ItemMeta    `json:"items,omitempty"`
-	MediaDetails map[string]MediaDetail `json:"media_details,omitempty"`
+	Items          map[string]ItemMeta    `json:"items,omitempty"`
+	MediaDetails   map[string]MediaDetail `json:"media_details,omitempty"`
+	Downloads      map[string]Download    `json:"downloads,omitempty"`
+	PendingReports []PendingReport        `json:"pending_reports,omitempty"`
+	Playbacks      []PlaybackRecord       `json:"playbacks,omitempty"`
+	Queue          []QueueEntry           `json:"queue,omitempty"`
+	SourcePrefs    map[string]string      `json:"source_prefs,omitempty"`
+	TrackPrefs     map[string]TrackPref   `json:"track_prefs,omitempty"`
+}
+
+type TrackPref struct {
+	AudioLang    string `json:"audio_lang,omitempty"`
+	SubtitleLang string `json:"subtitle_lang,omitempty"`
+	SubtitlesOff bool   `json:"subtitles_off,omitempty"`
 }
 
 var (
@@ -76,12 +177,50 @@ func init() {
 	}
 }
 
+func Dir() string {
+	return configDir
+}
+
+// CheckWritable creates and removes a probe file in the config directory and
+// in the custom data directory, if one is set.
+func (s *Store) CheckWritable() error {
+	if configDir == "" {
+		return errors.New("home directory is unknown")
+	}
+	dirs := []string{configDir}
+	s.mu.RLock()
+	if dir := s.config.Settings.DataDir; dir != "" {
+		dirs = append(dirs, dir)
+	}
+	s.mu.RUnlock()
+
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		probe, err := os.CreateTemp(dir, ".write-check-*")
+		if err != nil {
+			return err
+		}
+		probe.Close()
+		os.Remove(probe.Name())
+	}
+	return nil
+}
+
 type Store struct {
 	mu         sync.RWMutex
 	configPath string
 	config     ServerConfig
-	dataPath   string
+	backend    Backend
+	dataKey    string
 	data       ServerData
+
+	savedSecrets  map[string]string
+	unreadSecrets map[string]bool
+	keyringErr    error
+
+	cacheMu sync.Mutex
 }
 
 func (s *Store) validServerIndex(idx int) bool {
@@ -101,33 +240,123 @@ func (s *Store) ensureMediaDetailsMap() {
 }
 
 func New() (*Store, error) {
+	return NewWithBackend(nil)
+}
+
+func NewWithBackend(backend Backend) (*Store, error) {
 	s := &Store{
-		configPath: filepath.Join(configDir, "servers.json"),
+		configPath:    filepath.Join(configDir, "servers.json"),
+		savedSecrets:  make(map[string]string),
+		unreadSecrets: make(map[string]bool),
 	}
 	s.loadConfig()
+	if backend == nil {
+		backend = s.defaultBackend()
+	}
+	s.backend = backend
 	s.loadDataForActiveServer()
 	return s, nil
 }
 
+func (s *Store) defaultBackend() Backend {
+	backend, err := openBackend(s.config.Settings.StorageBackend, s.dataDir())
+	if err != nil {
+		logging.Storage("Storage backend unavailable, using JSON files", "backend", s.config.Settings.StorageBackend, "error", err)
+		return NewFileBackend(s.dataDir())
+	}
+	return backend
+}
+
+// dataDir is data_dir when it is set and usable, and the config directory
+// otherwise.
+func (s *Store) dataDir() string {
+	dir := s.config.Settings.DataDir
+	if dir == "" {
+		return configDir
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		logging.Storage("Data dir unavailable, using local data", "dir", dir, "error", err)
+		return configDir
+	}
+	return dir
+}
+
+func openBackend(name, dir string) (Backend, error) {
+	switch name {
+	case "", BackendJSON:
+		return NewFileBackend(dir), nil
+	case BackendSQLite:
+		return NewSQLiteBackend(dir)
+	}
+	return nil, fmt.Errorf("unknown storage backend: %s", name)
+}
+
+// SetStorageBackend switches to the named backend and copies the data of
+// every server into it, so nothing is left behind in the old one.
+func (s *Store) SetStorageBackend(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, err := openBackend(name, s.dataDir())
+	if err != nil {
+		return err
+	}
+	for i := range s.config.Servers {
+		key := s.config.Servers[i].Prefix()
+		data := s.data
+		if key != s.dataKey {
+			if data, err = s.backend.LoadData(key); err != nil {
+				continue
+			}
+		}
+		if err := next.SaveData(key, data); err != nil {
+			closeBackend(next)
+			return err
+		}
+	}
+	logging.Storage("Storage backend switched", "from", s.backend.Name(), "to", next.Name())
+	closeBackend(s.backend)
+	s.backend = next
+	s.config.Settings.StorageBackend = name
+	return s.saveConfig()
+}
+
+func closeBackend(b Backend) {
+	if c, ok := b.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+func (s *Store) BackendName() string {
+	return s.backend.Name()
+}
+
 func (s *Store) loadConfig() {
-	data, err := os.ReadFile(s.configPath)
+	config, err := readJSONWithBackup[ServerConfig](s.configPath)
 	if err != nil {
 		return
 	}
-	json.Unmarshal(data, &s.config)
+	s.config = config
+	if s.loadSecrets() {
+		_ = s.saveConfig()
+	}
 }
 
 func (s *Store) saveConfig() error {
-	data, err := json.MarshalIndent(s.config, "", "  ")
+	config := s.config
+	config.Servers = s.persistableServers()
+	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(s.configPath, data, 0644)
+	err = writeFileAtomic(s.configPath, data, 0600)
+	logging.Storage("Config saved", "path", s.configPath, "bytes", len(data), "error", err)
+	return err
 }
 
 func (s *Store) loadDataForActiveServer() {
 	if len(s.config.Servers) == 0 {
-		s.dataPath = ""
+		s.dataKey = ""
 		s.data = ServerData{}
 		return
 	}
@@ -137,26 +366,19 @@ func (s *Store) loadDataForActiveServer() {
 
 	srv := s.config.Servers[s.config.ActiveServer]
 
-	prefix := srv.Prefix()
-	s.dataPath = filepath.Join(configDir, "data_"+prefix+".json")
-
-	data, err := os.ReadFile(s.dataPath)
-	if err != nil {
-		s.data = ServerData{}
-		return
-	}
-	json.Unmarshal(data, &s.data)
+	s.dataKey = srv.Prefix()
+	data, err := s.backend.LoadData(s.dataKey)
+	logging.Storage("Loading server data", "backend", s.backend.Name(), "key", s.dataKey, "error", err)
+	s.data = data
 }
 
 func (s *Store) saveData() error {
-	if s.dataPath == "" {
+	if s.dataKey == "" {
 		return nil
 	}
-	data, err := json.MarshalIndent(s.data, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(s.dataPath, data, 0644)
+	err := s.backend.SaveData(s.dataKey, s.data)
+	logging.Storage("Data saved", "backend", s.backend.Name(), "key", s.dataKey, "error", err)
+	return err
 }
 
 func (s *Store) SetItemMeta(meta ItemMeta) {
@@ -181,6 +403,16 @@ func (s *Store) GetMediaDetail(itemID string) (MediaDetail, bool) {
 	return detail, ok
 }
 
+func (s *Store) GetMediaDetails() []MediaDetail {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	details := make([]MediaDetail, 0, len(s.data.MediaDetails))
+	for _, detail := range s.data.MediaDetails {
+		details = append(details, detail)
+	}
+	return details
+}
+
 func (s *Store) SetMediaDetail(detail MediaDetail) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -203,6 +435,30 @@ func (s *Store) UpdatePlaybackPosition(itemID string, positionSec, durationSec i
 	_ = s.saveData()
 }
 
+func (s *Store) ClearPlaybackPositions(itemIDs []string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cleared := 0
+	for _, id := range itemIDs {
+		detail, ok := s.data.MediaDetails[id]
+		if !ok || detail.PositionSec == 0 {
+			continue
+		}
+		if detail.SourceID == "" {
+			delete(s.data.MediaDetails, id)
+		} else {
+			detail.PositionSec = 0
+			detail.UpdatedAt = time.Now().Format(time.RFC3339)
+			s.data.MediaDetails[id] = detail
+		}
+		cleared++
+	}
+	if cleared > 0 {
+		_ = s.saveData()
+	}
+	return cleared
+}
+
 func (s *Store) GetPlaybackPosition(itemID string) int64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -213,7 +469,9 @@ func (s *Store) GetServers() []Server {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	servers := make([]Server, len(s.config.Servers))
-	copy(servers, s.config.Servers)
+	for i, srv := range s.config.Servers {
+		servers[i] = srv.clone()
+	}
 	return servers
 }
 
@@ -230,7 +488,11 @@ func (s *Store) UpdateServer(idx int, srv Server) {
 	if !s.validServerIndex(idx) {
 		return
 	}
+	old := s.config.Servers[idx]
 	s.config.Servers[idx] = srv
+	if keyringAccount(old) != keyringAccount(srv) {
+		s.deleteSecret(old)
+	}
 	_ = s.saveConfig()
 }
 
@@ -240,7 +502,9 @@ func (s *Store) DeleteServer(idx int) {
 	if !s.validServerIndex(idx) {
 		return
 	}
+	old := s.config.Servers[idx]
 	s.config.Servers = append(s.config.Servers[:idx], s.config.Servers[idx+1:]...)
+	s.deleteSecret(old)
 	s.config.ActiveServer = max(0, min(s.config.ActiveServer, len(s.config.Servers)-1))
 	_ = s.saveConfig()
 	s.loadDataForActiveServer()
@@ -256,7 +520,7 @@ func (s *Store) GetActiveServer() *Server {
 	if idx < 0 || idx >= len(s.config.Servers) {
 		idx = 0
 	}
-	srv := s.config.Servers[idx]
+	srv := s.config.Servers[idx].clone()
 	return &srv
 }
 
@@ -298,3 +562,155 @@ func (s *Store) SaveServerToken(idx int, userID, token string) {
 	}
 	_ = s.saveConfig()
 }
+
+func (s *Store) SetServerAuthFailed(idx int, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.validServerIndex(idx) || s.config.Servers[idx].AuthFailed == failed {
+		return
+	}
+	s.config.Servers[idx].AuthFailed = failed
+	_ = s.saveConfig()
+}
+
+func (s *Store) GetSettings() Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	settings := s.config.Settings
+	settings.DisabledLogCategories = append([]string(nil), settings.DisabledLogCategories...)
+	settings.MPVArgs = append([]string(nil), settings.MPVArgs...)
+	settings.SubtitleLangs = append([]string(nil), settings.SubtitleLangs...)
+	settings.AudioLangs = append([]string(nil), settings.AudioLangs...)
+	settings.HideWatchedViews = maps.Clone(settings.HideWatchedViews)
+	return settings
+}
+
+func (s *Store) UpdateSettings(update func(*Settings)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(&s.config.Settings)
+	_ = s.saveConfig()
+}
+
+func (s *Store) SetServerMaxBitrate(idx int, bitrate int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.validServerIndex(idx) {
+		return
+	}
+	s.config.Servers[idx].MaxBitrate = max(0, bitrate)
+	_ = s.saveConfig()
+}
+
+func (s *Store) SetDownload(d Download) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.Downloads == nil {
+		s.data.Downloads = make(map[string]Download)
+	}
+	s.data.Downloads[d.ItemID] = d
+	_ = s.saveData()
+}
+
+func (s *Store) GetDownload(itemID string) (Download, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	d, ok := s.data.Downloads[itemID]
+	return d, ok
+}
+
+func (s *Store) GetDownloads() []Download {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	downloads := make([]Download, 0, len(s.data.Downloads))
+	for _, d := range s.data.Downloads {
+		downloads = append(downloads, d)
+	}
+	return downloads
+}
+
+func (s *Store) DeleteDownload(itemID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data.Downloads, itemID)
+	_ = s.saveData()
+}
+
+func (s *Store) QueuePendingReport(r PendingReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reports := s.data.PendingReports[:0]
+	for _, existing := range s.data.PendingReports {
+		if existing.ItemID != r.ItemID {
+			reports = append(reports, existing)
+		}
+	}
+	s.data.PendingReports = append(reports, r)
+	_ = s.saveData()
+}
+
+func (s *Store) GetSourcePref(itemID string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sourceID, ok := s.data.SourcePrefs[itemID]
+	return sourceID, ok
+}
+
+func (s *Store) SetSourcePref(itemID, sourceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.SourcePrefs == nil {
+		s.data.SourcePrefs = make(map[string]string)
+	}
+	s.data.SourcePrefs[itemID] = sourceID
+	_ = s.saveData()
+}
+
+func (s *Store) GetTrackPref(seriesID string) (TrackPref, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	pref, ok := s.data.TrackPrefs[seriesID]
+	return pref, ok
+}
+
+func (s *Store) SetTrackPref(seriesID string, pref TrackPref) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.TrackPrefs == nil {
+		s.data.TrackPrefs = make(map[string]TrackPref)
+	}
+	s.data.TrackPrefs[seriesID] = pref
+	_ = s.saveData()
+}
+
+func (s *Store) GetQueue() []QueueEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]QueueEntry(nil), s.data.Queue...)
+}
+
+func (s *Store) SetQueue(queue []QueueEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Queue = append([]QueueEntry(nil), queue...)
+	_ = s.saveData()
+}
+
+func (s *Store) GetPendingReports() []PendingReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]PendingReport(nil), s.data.PendingReports...)
+}
+
+func (s *Store) RemovePendingReport(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reports := s.data.PendingReports[:0]
+	for _, existing := range s.data.PendingReports {
+		if existing.SessionID != sessionID {
+			reports = append(reports, existing)
+		}
+	}
+	s.data.PendingReports = reports
+	_ = s.saveData()
+}
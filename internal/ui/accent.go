@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	defaultAccent   = lipgloss.Color("212")
+	accentSamples   = 64
+	minAccentWeight = 0.02
+)
+
+var (
+	accentCache = newLRUCache[string, lipgloss.Color]("accents", accentCacheSize)
+
+	fixedAccent lipgloss.Color
+)
+
+func rememberAccent(url string, img image.Image) {
+	if _, ok := accentCache.Get(url); ok {
+		return
+	}
+
+	accent := defaultAccent
+	if c, ok := dominantColor(img); ok {
+		accent = accentColor(c)
+	}
+	accentCache.Put(url, accent)
+}
+
+func imageAccent(urls []string) (lipgloss.Color, bool) {
+	for _, url := range urls {
+		if accent, ok := accentCache.Get(url); ok {
+			return accent, true
+		}
+	}
+	return "", false
+}
+
+func (m *Model) accent() lipgloss.Color {
+	if fixedAccent != "" {
+		return fixedAccent
+	}
+	item, ok := m.currentItem()
+	if !ok {
+		return defaultAccent
+	}
+	return itemAccent(item)
+}
+
+func itemAccent(item service.MediaItem) lipgloss.Color {
+	if fixedAccent != "" {
+		return fixedAccent
+	}
+	if accent, ok := imageAccent(coverURLs(item)); ok {
+		return accent
+	}
+	return defaultAccent
+}
+
+func dominantColor(img image.Image) ([3]uint8, bool) {
+	bounds := img.Bounds()
+	stepX := max(bounds.Dx()/accentSamples, 1)
+	stepY := max(bounds.Dy()/accentSamples, 1)
+
+	type bucket struct {
+		weight  float64
+		r, g, b float64
+	}
+	buckets := make(map[int]*bucket)
+	var total float64
+	samples := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += stepY {
+		for x := bounds.Min.X; x < bounds.Max.X; x += stepX {
+			r16, g16, b16, _ := img.At(x, y).RGBA()
+			r, g, b := uint8(r16>>8), uint8(g16>>8), uint8(b16>>8)
+			samples++
+			hi, lo := max(r, g, b), min(r, g, b)
+			if hi < 40 || lo > 225 {
+				continue
+			}
+			saturation := float64(hi-lo) / float64(hi)
+			if saturation < 0.2 {
+				continue
+			}
+			key := int(r>>4)<<8 | int(g>>4)<<4 | int(b>>4)
+			bk := buckets[key]
+			if bk == nil {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.weight += saturation
+			bk.r += float64(r) * saturation
+			bk.g += float64(g) * saturation
+			bk.b += float64(b) * saturation
+			total += saturation
+		}
+	}
+
+	var best *bucket
+	for _, bk := range buckets {
+		if best == nil || bk.weight > best.weight {
+			best = bk
+		}
+	}
+	if best == nil || samples == 0 || total/float64(samples) < minAccentWeight {
+		return [3]uint8{}, false
+	}
+	return [3]uint8{
+		uint8(best.r / best.weight),
+		uint8(best.g / best.weight),
+		uint8(best.b / best.weight),
+	}, true
+}
+
+func accentColor(c [3]uint8) lipgloss.Color {
+	h, s, l := rgbToHSL(c)
+	s = math.Max(s, 0.45)
+	l = math.Min(math.Max(l, 0.55), 0.72)
+	r, g, b := hslToRGB(h, s, l)
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+}
+
+func rgbToHSL(c [3]uint8) (h, s, l float64) {
+	r, g, b := float64(c[0])/255, float64(c[1])/255, float64(c[2])/255
+	hi, lo := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (hi + lo) / 2
+	if hi == lo {
+		return 0, 0, l
+	}
+	d := hi - lo
+	if l > 0.5 {
+		s = d / (2 - hi - lo)
+	} else {
+		s = d / (hi + lo)
+	}
+	switch hi {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h / 6, s, l
+}
+
+func hslToRGB(h, s, l float64) (uint8, uint8, uint8) {
+	q := l * (1 + s)
+	if l >= 0.5 {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	channel := func(t float64) uint8 {
+		t = math.Mod(t+1, 1)
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+		case t < 0.5:
+			v = q
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+		default:
+			v = p
+		}
+		return uint8(math.Round(v * 255))
+	}
+	return channel(h + 1.0/3), channel(h), channel(h - 1.0/3)
+}
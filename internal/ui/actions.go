@@ -2,6 +2,7 @@ package ui
 
 import (
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"ember/internal/player"
@@ -20,9 +21,12 @@ func (m *Model) selectItem() (tea.Model, tea.Cmd) {
 	item := m.items[m.cursor]
 
 	switch item.Type {
-	case "Movie", "Episode", "Video":
+	case "Movie", "Episode", "Video", "TvChannel":
 		return m.playItem(item, false)
 
+	case "Audio":
+		return m.playAlbum(item)
+
 	case "Series":
 		m.pushNav()
 		m.page = 0
@@ -41,7 +45,24 @@ func (m *Model) selectItem() (tea.Model, tea.Cmd) {
 		m.view = viewState{mode: viewEpisodes, seriesID: seriesID, seasonID: item.ID}
 		return m, m.loadEpisodes(seriesID, item.ID)
 
-	case "CollectionFolder", "Folder", "BoxSet":
+	case "Studio":
+		m.pushNav()
+		m.currentLib = &item
+		m.page = 0
+		m.state = StateLoading
+		m.view = viewState{mode: viewStudio, parentID: item.ID}
+		return m, m.loadStudioItems(item.ID, 0)
+
+	case "Genre":
+		parentID := m.view.parentID
+		m.pushNav()
+		m.currentLib = &item
+		m.page = 0
+		m.state = StateLoading
+		m.view = viewState{mode: viewGenre, parentID: parentID, genreID: item.ID}
+		return m, m.loadGenreItems(parentID, item.ID, 0)
+
+	case "CollectionFolder", "Folder", "BoxSet", "MusicAlbum", "Playlist":
 		m.pushNav()
 		m.currentLib = &item
 		m.page = 0
@@ -54,6 +75,20 @@ func (m *Model) selectItem() (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) playItem(item service.MediaItem, fromBeginning bool) (tea.Model, tea.Cmd) {
+	if fromBeginning {
+		return m.playItemAt(item, 0, "Launching MPV from beginning: "+item.Name)
+	}
+	return m.playItemAt(item, -1, "Launching MPV: "+item.Name)
+}
+
+func (m *Model) playItemAt(item service.MediaItem, startSec int64, status string) (tea.Model, tea.Cmd) {
+	if item.Type == "Audio" {
+		return m.playAlbum(item)
+	}
+	if m.svc.NeedsSourceChoice(item) {
+		return m.openVersions(item, startSec, status)
+	}
+
 	streamInfo, err := m.svc.GetStreamInfoForItem(item)
 	if err != nil {
 		m.status = "Cannot play: " + err.Error()
@@ -66,20 +101,23 @@ func (m *Model) playItem(item service.MediaItem, fromBeginning bool) (tea.Model,
 	durationTicks := streamInfo.Duration
 	startPosSec := streamInfo.PositionSec
 	subtitleURLs := streamInfo.SubtitleURLs
-	if fromBeginning {
-		startPosSec = 0
+	if startSec >= 0 {
+		startPosSec = startSec
 	}
 
-	if fromBeginning {
-		m.status = "Launching MPV from beginning: " + item.Name
-	} else {
-		m.status = "Launching MPV: " + item.Name
+	m.status = status
+	if streamInfo.PlayMethod == "Transcode" {
+		m.status += " (transcoding, T to inspect)"
 	}
+	m.nowPlaying = service.MediaTitle(item)
+	m.playing = &item
+	m.playMethod = streamInfo.PlayMethod
+	m.playMarkers = nil
 
-	return m, func() tea.Msg {
+	return m, tea.Batch(tickPlayback(), m.loadPlaybackMarkers(item), func() tea.Msg {
 		result := player.PlayWithHook(streamInfo.StreamURL, item.Name, subtitleURLs, startPosSec, func() {
 			_ = m.svc.ReportPlaybackStart(itemID, mediaSourceID, sessionID, startPosSec)
-		})
+		}, m.svc.PlayerMetadata(item))
 		err := m.svc.ReportPlaybackStopped(itemID, mediaSourceID, sessionID, result.PositionSec, durationTicks)
 
 		return playDoneMsg{
@@ -87,9 +125,9 @@ func (m *Model) playItem(item service.MediaItem, fromBeginning bool) (tea.Model,
 			positionSec:   result.PositionSec,
 			durationTicks: durationTicks,
 			reportOK:      err == nil,
-			err:           result.Err,
+			err:           m.svc.DiagnosePlayback(streamInfo.StreamURL, result.Err),
 		}
-	}
+	})
 }
 
 func (m *Model) playSeasonContinuously(item service.MediaItem) tea.Cmd {
@@ -103,33 +141,90 @@ func (m *Model) playSeasonContinuously(item service.MediaItem) tea.Cmd {
 		m.status = "Cannot play continuously: missing season info"
 		return nil
 	}
+	m.nowPlaying = service.MediaTitle(item)
 
-	return func() tea.Msg {
+	return tea.Batch(tickPlayback(), func() tea.Msg {
 		plan, err := m.svc.BuildContinuousPlayback(item)
 		if err != nil {
 			return playDoneMsg{err: err}
 		}
+		return m.playPlan(plan, nil)
+	})
+}
 
-		startPosSec := plan.StreamInfo.PositionSec
-		playSessionID := strings.ReplaceAll(uuid.New().String(), "-", "")
-		result := player.PlayMultipleWithHook(plan.URLs, plan.Title, nil, startPosSec, plan.StartIndex, func() {
-			_ = m.svc.ReportPlaybackStart(plan.CurrentItem.ID, plan.StreamInfo.MediaSourceID, playSessionID, startPosSec)
-		})
+func (m *Model) playQueue(startIndex int) tea.Cmd {
+	m.nowPlaying = "Queue"
 
-		durationTicks := plan.CurrentItem.RunTimeTicks
-		reportOK := result.Err == nil
-		if plan.CurrentItem.ID != "" && result.PositionSec > 0 {
-			reportOK = m.svc.ReportPlaybackStopped(plan.CurrentItem.ID, plan.StreamInfo.MediaSourceID, playSessionID, result.PositionSec, durationTicks) == nil && reportOK
+	return tea.Batch(tickPlayback(), func() tea.Msg {
+		plan, err := m.svc.BuildQueuePlayback(startIndex)
+		if err != nil {
+			return playDoneMsg{err: err}
 		}
+		return m.playPlan(plan, m.svc.FinishQueuePlayback)
+	})
+}
 
-		return playDoneMsg{
-			itemID:        plan.CurrentItem.ID,
-			positionSec:   result.PositionSec,
-			durationTicks: durationTicks,
-			reportOK:      reportOK,
-			err:           result.Err,
+func (m *Model) playPlan(plan *service.ContinuousPlaybackPlan, onFinish func(*service.ContinuousPlaybackPlan, int, int64)) tea.Msg {
+	startPosSec := plan.StreamInfo.PositionSec
+	sessionIDs := make([]string, max(len(plan.Items), plan.StartIndex+1))
+	for i := range sessionIDs {
+		sessionIDs[i] = strings.ReplaceAll(uuid.New().String(), "-", "")
+	}
+	var switchFailed atomic.Bool
+	result := player.PlayMultipleTracked(plan.URLs, plan.Title, nil, startPosSec, plan.StartIndex, func() {
+		_ = m.svc.ReportPlaybackStart(plan.CurrentItem.ID, plan.StreamInfo.MediaSourceID, sessionIDs[plan.StartIndex], startPosSec)
+	}, func(from, to int, positionSec int64) {
+		if from >= 0 && from < len(plan.Items) {
+			prev := plan.Items[from]
+			if m.svc.ReportPlaybackStopped(prev.ID, m.planSourceID(plan, from), sessionIDs[from], positionSec, prev.RunTimeTicks) != nil {
+				switchFailed.Store(true)
+			}
 		}
+		if to >= 0 && to < len(plan.Items) {
+			_ = m.svc.ReportPlaybackStart(plan.Items[to].ID, m.planSourceID(plan, to), sessionIDs[to], 0)
+		}
+	}, plan.Metadata...)
+
+	last, lastIndex := plan.CurrentItem, plan.StartIndex
+	if result.Index >= 0 && result.Index < len(plan.Items) {
+		last, lastIndex = plan.Items[result.Index], result.Index
+	}
+	durationTicks := last.RunTimeTicks
+	reportOK := result.Err == nil && !switchFailed.Load()
+	if last.ID != "" && result.PositionSec > 0 {
+		reportOK = m.svc.ReportPlaybackStopped(last.ID, m.planSourceID(plan, lastIndex), sessionIDs[lastIndex], result.PositionSec, durationTicks) == nil && reportOK
+	}
+	if onFinish != nil && result.Err == nil {
+		onFinish(plan, lastIndex, result.PositionSec)
+	}
+
+	return playDoneMsg{
+		itemID:        last.ID,
+		positionSec:   result.PositionSec,
+		durationTicks: durationTicks,
+		reportOK:      reportOK,
+		err:           m.svc.DiagnosePlayback(plan.StreamInfo.StreamURL, result.Err),
+	}
+}
+
+func (m *Model) planSourceID(plan *service.ContinuousPlaybackPlan, index int) string {
+	if index == plan.StartIndex {
+		return plan.StreamInfo.MediaSourceID
+	}
+	if index < 0 || index >= len(plan.Items) || len(plan.Items[index].MediaSources) == 0 {
+		return ""
 	}
+	ms, _ := m.svc.PreferredSource(plan.Items[index])
+	return ms.ID
+}
+
+func (m *Model) moveCursor(delta int) (tea.Model, tea.Cmd) {
+	next := m.cursor + delta
+	if next < 0 || next >= len(m.items) {
+		return m, nil
+	}
+	m.cursor = next
+	return m, tea.Batch(m.loadVisibleImages(), m.loadMore())
 }
 
 func (m *Model) goBack() (tea.Model, tea.Cmd) {
@@ -181,7 +276,26 @@ func (m *Model) goToSeries(item service.MediaItem) tea.Cmd {
 	}
 }
 
+func (m *Model) openGenres() (tea.Model, tea.Cmd) {
+	if m.view.mode == viewGenres {
+		return m, nil
+	}
+	parentID := ""
+	if m.view.mode == viewItems && m.currentLib != nil {
+		parentID = m.currentLib.ID
+	}
+	m.pushNav()
+	if parentID == "" {
+		m.currentLib = nil
+	}
+	m.page = 0
+	m.state = StateLoading
+	m.view = viewState{mode: viewGenres, parentID: parentID}
+	return m, m.loadGenres(parentID, 0)
+}
+
 func (m *Model) pushNav() {
+	m.clearQuickFilter()
 	m.navStack = append(m.navStack, NavState{
 		Section:    m.section,
 		View:       m.view,
@@ -204,7 +318,8 @@ func (m *Model) resetForServerSwitch(samePrefix bool) {
 	m.cursor = 0
 	m.sectionCache = make(map[Section][]service.MediaItem)
 	m.sectionCursor = make(map[Section]int)
-	m.coverCache = make(map[string]string)
+	m.sectionMore = make(map[Section]string)
+	m.coverCache.Clear()
 
 	if !samePrefix {
 		m.detailCache = make(map[string]*storage.MediaDetail)
@@ -236,6 +351,42 @@ func (m *Model) syncItemState(itemID string, updater func(*service.MediaItem)) {
 	}
 }
 
+func (m *Model) watchedScope() (string, bool) {
+	switch m.view.mode {
+	case viewItems:
+		return service.LibraryWatchedScope(m.view.parentID), true
+	case viewSeasons, viewEpisodes:
+		return service.SeriesWatchedScope(m.view.seriesID), true
+	case viewFavorites:
+		return service.WatchedScopeFavorites, true
+	}
+	return "", false
+}
+
+func (m *Model) toggleHideWatched() (tea.Model, tea.Cmd) {
+	scope, ok := m.watchedScope()
+	if !ok {
+		m.status = "Watched items can be hidden in libraries, series and Favorites"
+		return m, nil
+	}
+
+	hide := !m.svc.HideWatchedIn(scope)
+	m.svc.SetHideWatchedIn(scope, hide)
+	delete(m.sectionCache, SectionFavorites)
+
+	m.state = StateLoading
+	m.keepCursor = false
+	m.cursor = 0
+	m.page = 0
+	cmd := m.loadActiveView()
+	if hide {
+		m.status = "Hiding watched items in this view"
+	} else {
+		m.status = "Showing watched items in this view"
+	}
+	return m, cmd
+}
+
 func (m *Model) refreshCurrentView() (tea.Model, tea.Cmd) {
 	m.state = StateLoading
 	m.keepCursor = true
@@ -257,6 +408,33 @@ func (m *Model) loadActiveView() tea.Cmd {
 	case viewHistory:
 		return m.loadHistory(m.page)
 
+	case viewReleased:
+		return m.loadReleased(m.page)
+
+	case viewStudios:
+		return m.loadStudios(m.page)
+
+	case viewStudio:
+		return m.loadStudioItems(m.view.parentID, m.page)
+
+	case viewGenres:
+		return m.loadGenres(m.view.parentID, m.page)
+
+	case viewGenre:
+		return m.loadGenreItems(m.view.parentID, m.view.genreID, m.page)
+
+	case viewDownloads:
+		return m.loadDownloads()
+
+	case viewAiring:
+		return m.loadAiring(m.page)
+
+	case viewChannels:
+		return m.loadChannels(m.page)
+
+	case viewLatest:
+		return m.loadLatest()
+
 	case viewSearch:
 		if m.hasSearchCriteria() {
 			return m.searchItems()
@@ -280,6 +458,7 @@ func (m *Model) loadCurrentPagedSection() tea.Cmd {
 }
 
 func (m *Model) switchSection(target Section, loader func() tea.Cmd) (tea.Model, tea.Cmd) {
+	m.clearQuickFilter()
 	m.sectionCursor[m.section] = m.cursor
 
 	m.section = target
@@ -296,6 +475,18 @@ func (m *Model) switchSection(target Section, loader func() tea.Cmd) (tea.Model,
 		m.view = viewState{mode: viewHistory}
 	case SectionSearch:
 		m.view = viewState{mode: viewSearch}
+	case SectionReleased:
+		m.view = viewState{mode: viewReleased}
+	case SectionStudios:
+		m.view = viewState{mode: viewStudios}
+	case SectionDownloads:
+		m.view = viewState{mode: viewDownloads}
+	case SectionAiring:
+		m.view = viewState{mode: viewAiring}
+	case SectionLiveTV:
+		m.view = viewState{mode: viewChannels}
+	case SectionLatest:
+		m.view = viewState{mode: viewLatest}
 	}
 
 	if (target == SectionResume || target == SectionFavorites) && len(m.navStack) == 0 {
@@ -303,6 +494,7 @@ func (m *Model) switchSection(target Section, loader func() tea.Cmd) (tea.Model,
 			m.items = cached
 			m.totalItems = len(cached)
 			m.cursor = m.sectionCursor[target]
+			m.moreAfter = m.sectionMore[target]
 			m.state = StateBrowsing
 			m.status = ""
 			return m, m.loadVisibleImages()
@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"ember/internal/logging"
+	"ember/internal/script"
 	"ember/internal/service"
 	"ember/internal/storage"
 
@@ -21,6 +24,12 @@ const (
 	SectionFavorites
 	SectionHistory
 	SectionSearch
+	SectionReleased
+	SectionStudios
+	SectionDownloads
+	SectionAiring
+	SectionLiveTV
+	SectionLatest
 )
 
 type State int
@@ -31,6 +40,21 @@ const (
 	StateSearching
 	StateServerManage
 	StateServerEdit
+	StateSettings
+	StateDetail
+	StateFilter
+	StateLogPicker
+	StateSeasonPicker
+	StateKioskPicker
+	StateKioskPIN
+	StateScriptPicker
+	StateQueue
+	StateGuide
+	StateSessions
+	StateTranscodes
+	StateVersions
+	StateAudit
+	StateBulkPlayed
 )
 
 type viewMode int
@@ -43,6 +67,15 @@ const (
 	viewItems
 	viewSeasons
 	viewEpisodes
+	viewReleased
+	viewStudios
+	viewStudio
+	viewGenres
+	viewLatest
+	viewGenre
+	viewDownloads
+	viewAiring
+	viewChannels
 )
 
 type viewState struct {
@@ -50,6 +83,7 @@ type viewState struct {
 	parentID string
 	seriesID string
 	seasonID string
+	genreID  string
 }
 
 type Model struct {
@@ -72,20 +106,30 @@ type Model struct {
 
 	searchInput     textinput.Model
 	lastSearchQuery string
+	deepSearch      bool
 	spinner         spinner.Model
 	status          string
+	toasts          []toast
+	toastSeq        int
 	latency         time.Duration
 
-	coverCache  map[string]string
+	coverCache  *lruCache[imageKey, string]
+	images      *imagePool
 	detailCache map[string]*storage.MediaDetail
 
 	sectionCache  map[Section][]service.MediaItem
 	sectionCursor map[Section]int
+	sectionMore   map[Section]string
+	moreAfter     string
+	loadingMore   bool
 
 	lastPlayPosition int64
 	lastReportOK     bool
 	loggingEnabled   bool
 	helpVisible      bool
+	helpOffset       int
+	gridMode         bool
+	mini             bool
 
 	serverCursor     int
 	serverInputs     []textinput.Model
@@ -94,6 +138,104 @@ type Model struct {
 	serverLatencies  map[int]time.Duration
 	pingInProgress   bool
 	prevServerPrefix string
+
+	settingsCursor int
+
+	detailItem   *service.MediaItem
+	detailCursor int
+	similar      []service.MediaItem
+	similarIndex int
+
+	itemFilter   service.ItemFilter
+	filterDraft  service.ItemFilter
+	filterCursor int
+	filterInputs []textinput.Model
+
+	logCursor int
+
+	events <-chan service.Event
+
+	version       string
+	latestVersion string
+
+	prerenderGen atomic.Int64
+
+	downloads        map[string]*downloadTask
+	pendingDownloads []service.MediaItem
+	bulkDownloadID   string
+
+	syncingReports bool
+
+	nowPlaying  string
+	playing     *service.MediaItem
+	playMethod  string
+	playMarkers []service.PlaybackMarker
+	restartItem *service.MediaItem
+	transcodes  []service.TranscodeInfo
+
+	versionPlay    *pendingPlay
+	versionCursor  int
+	versionCompare bool
+	audioPlan      *service.ContinuousPlaybackPlan
+	musicView      bool
+
+	guideRows   []service.GuideRow
+	guideCursor int
+	guideStart  time.Time
+
+	sessions      []service.SessionInfo
+	sessionCursor int
+
+	keymap map[string]string
+
+	bulkPlayedItem service.MediaItem
+	bulkPlayed     *bulkPlayedRun
+
+	auditEntries []storage.AuditEntry
+	auditCursor  int
+	auditQuery   textinput.Model
+
+	command        textinput.Model
+	commandHistory []string
+	commandRecall  int
+	commandTyped   string
+	commandChoice  int
+
+	jumpPending bool
+	quickFilter textinput.Model
+	quickBase   []service.MediaItem
+	quickQuery  string
+
+	maintenance service.Maintenance
+
+	localHistory bool
+
+	latestByLibrary bool
+
+	seasonPicker seasonPickerMsg
+	seasonCursor int
+
+	kiosk       *service.KioskLock
+	kioskLibs   []service.MediaItem
+	kioskCursor int
+	kioskInput  textinput.Model
+	kioskTarget *service.MediaItem
+
+	scripts       *script.Engine
+	scriptItem    service.MediaItem
+	scriptActions []script.Action
+	scriptCursor  int
+	scriptErr     string
+
+	hiddenItems int
+
+	queueItems  []service.MediaItem
+	queueCursor int
+
+	lastInput   time.Time
+	prewarmGen  atomic.Int64
+	prewarming  bool
+	prewarmDone bool
 }
 
 type NavState struct {
@@ -107,14 +249,16 @@ type NavState struct {
 }
 
 type itemsMsg struct {
-	items []service.MediaItem
-	total int
-	err   error
-	view  *viewState
+	items  []service.MediaItem
+	total  int
+	hidden int
+	next   string
+	err    error
+	view   *viewState
 }
 
 type imageMsg struct {
-	id    string
+	key   imageKey
 	image string
 }
 
@@ -123,7 +267,10 @@ type detailMsg struct {
 	detail *storage.MediaDetail
 }
 
-type pingMsg time.Duration
+type pingMsg struct {
+	latency     time.Duration
+	maintenance service.Maintenance
+}
 
 type pingServersMsg struct {
 	latencies map[int]time.Duration
@@ -135,11 +282,22 @@ type favoriteMsg struct {
 	err    error
 }
 
+type playedMsg struct {
+	itemID string
+	played bool
+	err    error
+}
+
 type connectServerMsg struct {
 	err        error
 	samePrefix bool
 }
 
+type quickResumeMsg struct {
+	item *service.MediaItem
+	err  error
+}
+
 type playDoneMsg struct {
 	itemID        string
 	positionSec   int64
@@ -173,7 +331,7 @@ func New(svc *service.MediaService) *Model {
 		initialState = StateServerManage
 	}
 
-	return &Model{
+	m := &Model{
 		svc:             svc,
 		section:         SectionResume,
 		state:           initialState,
@@ -182,34 +340,48 @@ func New(svc *service.MediaService) *Model {
 		searchInput:     ti,
 		spinner:         sp,
 		status:          "Connecting...",
-		coverCache:      make(map[string]string),
+		coverCache:      newLRUCache[imageKey, string]("covers", coverCacheSize),
+		images:          newImagePool(imageWorkers),
 		detailCache:     make(map[string]*storage.MediaDetail),
 		sectionCache:    make(map[Section][]service.MediaItem),
 		sectionCursor:   make(map[Section]int),
+		sectionMore:     make(map[Section]string),
 		loggingEnabled:  true,
 		editingServer:   -1,
 		serverLatencies: make(map[int]time.Duration),
+		lastInput:       time.Now(),
+	}
+	if lock, ok := svc.Kiosk(); ok && initialState == StateLoading {
+		m.kiosk = &lock
+		m.showKioskLibrary()
 	}
+	return m
 }
 
 func (m *Model) Init() tea.Cmd {
 	if m.state == StateServerManage {
-		return m.spinner.Tick
+		return tea.Batch(m.spinner.Tick, waitForEvent(m.events), waitForImage(m.images), m.keepAlive(), tickPrewarm())
 	}
 	return tea.Batch(
-		m.loadResume(),
+		m.loadActiveView(),
 		m.pingServer(),
 		m.spinner.Tick,
+		waitForEvent(m.events),
+		waitForImage(m.images),
+		m.checkUpdate(),
+		m.syncPendingReports(),
+		m.keepAlive(),
+		tickPrewarm(),
 	)
 }
 
 func (m *Model) loadResume() tea.Cmd {
 	return func() tea.Msg {
-		list, err := m.svc.GetResume(50)
+		list, err := m.svc.GetResume(homePageSize)
 		if err != nil {
 			return itemsMsg{err: err}
 		}
-		return itemsMsg{items: list.Items, total: list.Total}
+		return itemsMsg{items: list.Items, total: len(list.Items), next: list.Next}
 	}
 }
 
@@ -225,17 +397,92 @@ func (m *Model) loadLibraries() tea.Cmd {
 
 func (m *Model) loadItems(parentID string, page int) tea.Cmd {
 	return func() tea.Msg {
-		list, err := m.svc.GetItems(parentID, page, m.pageSize)
+		list, err := m.svc.GetItems(parentID, page, m.pageSize, m.itemFilter)
 		if err != nil {
 			return itemsMsg{err: err}
 		}
-		return itemsMsg{items: list.Items, total: list.Total}
+		return itemsMsg{items: list.Items, total: list.Total, hidden: list.Hidden}
 	}
 }
 
 func (m *Model) loadHistory(page int) tea.Cmd {
 	return func() tea.Msg {
-		list, err := m.svc.GetHistory(page, m.pageSize)
+		getHistory := m.svc.GetHistory
+		if m.localHistory {
+			getHistory = m.svc.GetPlaybackLog
+		}
+		list, err := getHistory(page, m.pageSize)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) loadReleased(page int) tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetRecentlyReleased(page, m.pageSize)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) loadLatest() tea.Cmd {
+	byLibrary := m.latestByLibrary
+	return func() tea.Msg {
+		list, err := m.svc.GetLatest(byLibrary)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) loadAiring(page int) tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetAiringSoon(page, m.pageSize)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) loadStudios(page int) tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetStudios(page, m.pageSize)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) loadStudioItems(studioID string, page int) tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetStudioItems(studioID, page, m.pageSize)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) loadGenres(parentID string, page int) tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetGenres(parentID, page, m.pageSize)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) loadGenreItems(parentID, genreID string, page int) tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetGenreItems(parentID, genreID, page, m.pageSize)
 		if err != nil {
 			return itemsMsg{err: err}
 		}
@@ -243,7 +490,31 @@ func (m *Model) loadHistory(page int) tea.Cmd {
 	}
 }
 
+func (m *Model) quickResume() tea.Cmd {
+	return func() tea.Msg {
+		item, err := m.svc.LastResumeItem()
+		return quickResumeMsg{item: item, err: err}
+	}
+}
+
+func (m *Model) loadDownloads() tea.Cmd {
+	return func() tea.Msg {
+		list := m.svc.GetDownloadedItems()
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
 func (m *Model) searchItems() tea.Cmd {
+	if m.deepSearch {
+		query := m.lastSearchQuery
+		return func() tea.Msg {
+			list, err := m.svc.DeepSearch(query, m.pageSize)
+			if err != nil {
+				return itemsMsg{err: err}
+			}
+			return itemsMsg{items: list.Items, total: list.Total}
+		}
+	}
 	return func() tea.Msg {
 		list, err := m.svc.SearchWithOptions(service.SearchQuery{
 			Query: m.lastSearchQuery,
@@ -263,7 +534,7 @@ func (m *Model) loadSeasons(seriesID string) tea.Cmd {
 		if err != nil {
 			return itemsMsg{err: err}
 		}
-		return itemsMsg{items: list.Items, total: list.Total}
+		return itemsMsg{items: list.Items, total: list.Total, hidden: list.Hidden}
 	}
 }
 
@@ -273,17 +544,17 @@ func (m *Model) loadEpisodes(seriesID, seasonID string) tea.Cmd {
 		if err != nil {
 			return itemsMsg{err: err}
 		}
-		return itemsMsg{items: list.Items, total: list.Total}
+		return itemsMsg{items: list.Items, total: list.Total, hidden: list.Hidden}
 	}
 }
 
 func (m *Model) loadFavorites() tea.Cmd {
 	return func() tea.Msg {
-		list, err := m.svc.GetFavorites(50)
+		list, err := m.svc.GetFavorites(homePageSize)
 		if err != nil {
 			return itemsMsg{err: err}
 		}
-		return itemsMsg{items: list.Items, total: list.Total}
+		return itemsMsg{items: list.Items, total: len(list.Items), hidden: list.Hidden, next: list.Next}
 	}
 }
 
@@ -302,30 +573,67 @@ func (m *Model) toggleFavorite(item service.MediaItem) tea.Cmd {
 	}
 }
 
+func (m *Model) togglePlayed(item service.MediaItem) tea.Cmd {
+	target := true
+	if item.UserData != nil {
+		target = !item.UserData.Played
+	}
+
+	return func() tea.Msg {
+		result, err := m.svc.SetPlayed(item.ID, target)
+		if err != nil {
+			return playedMsg{itemID: item.ID, err: err}
+		}
+		return playedMsg{itemID: item.ID, played: result.Played}
+	}
+}
+
 func (m *Model) pingServer() tea.Cmd {
 	return func() tea.Msg {
 		status := m.svc.GetServerStatus()
-		return pingMsg(status.Latency)
+		return pingMsg{latency: time.Duration(status.Latency), maintenance: status.Maintenance}
 	}
 }
 
-func (m *Model) loadImage(item service.MediaItem, width, height int) tea.Cmd {
-	return func() tea.Msg {
-		if width <= 0 || height <= 0 {
-			return imageMsg{id: item.ID, image: ""}
-		}
+// requestImage hands a cover to the image pool unless it is cached. Items
+// without a picture are cached as empty right away.
+func (m *Model) requestImage(key imageKey, item service.MediaItem) {
+	if CurrentImageQuality() == ImageQualityOff || m.coverCached(key) {
+		return
+	}
+	urls := coverURLs(item)
+	if key.width <= 0 || key.height <= 0 || len(urls) == 0 {
+		m.coverCache.Put(key, "")
+		return
+	}
+	m.images.Request(key, urls)
+}
 
-		urls := item.ImageURLs
-		if len(urls) == 0 && item.ImageURL != "" {
-			urls = []string{item.ImageURL}
-		}
-		if len(urls) == 0 {
-			return imageMsg{id: item.ID, image: ""}
-		}
+type coverRequest struct {
+	key  imageKey
+	item service.MediaItem
+}
 
-		img := RenderImage(urls, width, height)
-		return imageMsg{id: item.ID, image: img}
+// carouselCovers lists the selected cover and its two neighbours on each
+// side, nearest first, so the pool draws what is on screen before what
+// might be next.
+func (m *Model) carouselCovers() []coverRequest {
+	coverWidth, coverHeight := m.coverFrame(m.contentSize())
+	if coverWidth <= 0 || coverHeight <= 0 {
+		return nil
+	}
+	var covers []coverRequest
+	for _, offset := range []int{0, 1, -1, 2, -2} {
+		if i := m.cursor + offset; i >= 0 && i < len(m.items) {
+			covers = append(covers, coverRequest{coverKey(m.items[i], coverWidth, coverHeight), m.items[i]})
+		}
 	}
+	return covers
+}
+
+func (m *Model) coverCached(key imageKey) bool {
+	_, ok := m.coverCache.Get(key)
+	return ok
 }
 
 func (m *Model) loadDetail(itemID string) tea.Cmd {
@@ -343,33 +651,55 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.coverCache = make(map[string]string)
 		return m, m.loadVisibleImages()
 
 	case tea.KeyMsg:
+		m.noteInput()
+		if m.command.Focused() {
+			return m.handleCommandKey(msg)
+		}
+		if m.quickFilter.Focused() {
+			return m.handleQuickFilterKey(msg)
+		}
+		msg = m.remapKey(msg)
 		if m.helpVisible {
 			if msg.String() == "?" || msg.String() == "esc" {
 				m.helpVisible = false
 			}
+			m.scrollHelp(msg.String())
 			return m, nil
 		}
-		if m.state != StateSearching && msg.String() == "?" {
+		if m.state != StateSearching && m.state != StateFilter && msg.String() == "?" {
 			m.helpVisible = true
+			m.helpOffset = 0
 			return m, nil
 		}
 		return m.handleKey(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case itemsMsg:
 		if msg.err != nil {
+			logging.UI("Load failed", "view", m.view.mode, "error", msg.err)
 			m.state = StateBrowsing
 			m.keepCursor = false
 			m.status = m.loadErrorText(msg.err)
+			if m.maintenance == service.MaintenanceRestarting || service.IsMaintenanceError(msg.err) {
+				m.maintenance = service.MaintenanceRestarting
+				m.status = "Server restarting, retrying..."
+			}
 		} else {
+			logging.UI("Items loaded", "view", m.view.mode, "count", len(msg.items), "total", msg.total)
 			if msg.view != nil {
 				m.view = *msg.view
 			}
+			m.clearQuickFilter()
 			m.items = msg.items
 			m.totalItems = msg.total
+			m.hiddenItems = msg.hidden
+			m.moreAfter = msg.next
+			m.loadingMore = false
 			if len(msg.items) == 0 {
 				m.cursor = 0
 			} else if m.keepCursor && m.cursor < len(msg.items) {
@@ -384,14 +714,60 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.section == SectionResume || m.section == SectionFavorites {
 				m.sectionCache[m.section] = msg.items
 				m.sectionCursor[m.section] = m.cursor
+				m.sectionMore[m.section] = msg.next
 			}
+			return m, tea.Sequence(m.loadVisibleImages(), m.prerenderCovers())
 		}
 		return m, m.loadVisibleImages()
 
-	case imageMsg:
-		m.coverCache[msg.id] = msg.image
+	case moreItemsMsg:
+		return m, m.applyMoreItems(msg)
+
+	case prerenderDoneMsg:
+		return m, nil
+
+	case prewarmTickMsg:
+		return m, tea.Batch(m.maybePrewarm(), tickPrewarm())
+
+	case prewarmDoneMsg:
+		m.applyPrewarmDone(msg)
 		return m, nil
 
+	case downloadTickMsg:
+		if len(m.downloads) == 0 {
+			return m, nil
+		}
+		m.status = m.downloadStatus()
+		return m, tickDownloads()
+
+	case downloadDoneMsg:
+		delete(m.downloads, msg.itemID)
+		if len(m.downloads) == 0 {
+			m.status = ""
+		}
+		var toast tea.Cmd
+		if msg.err != nil {
+			toast = m.notifyError("Download failed: " + msg.err.Error())
+		} else {
+			toast = m.notify("Downloaded " + msg.name + " to " + msg.path)
+		}
+		if msg.itemID == m.bulkDownloadID {
+			m.bulkDownloadID = ""
+			return m, tea.Batch(toast, m.nextPendingDownload())
+		}
+		return m, toast
+
+	case toastExpiredMsg:
+		m.expireToast(msg.id)
+		return m, nil
+
+	case bulkDownloadMsg:
+		return m, m.applyBulkDownload(msg)
+
+	case imageMsg:
+		m.coverCache.Put(msg.key, msg.image)
+		return m, waitForImage(m.images)
+
 	case detailMsg:
 		if msg.detail != nil {
 			m.detailCache[msg.id] = msg.detail
@@ -399,40 +775,72 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case pingMsg:
-		m.latency = time.Duration(msg)
-		return m, tea.Tick(10*time.Second, func(t time.Time) tea.Msg {
-			return pingMsg(m.svc.GetServerStatus().Latency)
-		})
+		m.latency = msg.latency
+		return m, m.applyPing(msg)
+
+	case keepAliveMsg:
+		return m, m.applyKeepAlive(msg)
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case playbackTickMsg:
+		if m.nowPlaying == "" {
+			return m, nil
+		}
+		m.loadTrackArt()
+		return m, tickPlayback()
+
+	case audioPlanMsg:
+		return m, m.applyAudioPlan(msg)
+
+	case playbackMarkersMsg:
+		if m.playing != nil && m.playing.ID == msg.itemID {
+			m.playMarkers = msg.markers
+		}
+		return m, nil
+
 	case playDoneMsg:
+		m.nowPlaying = ""
+		m.playing = nil
+		m.playMethod = ""
+		m.playMarkers = nil
+		m.audioPlan = nil
+		m.musicView = false
+		logging.UI("Playback finished", "item", msg.itemID, "position", msg.positionSec, "error", msg.err)
 		m.lastPlayPosition = msg.positionSec
 		m.lastReportOK = msg.reportOK
+		var toast tea.Cmd
 		if msg.err != nil {
-			m.status = "Playback failed: " + msg.err.Error()
+			reason := msg.err.Error()
+			if remedy := service.ErrorRemedy(msg.err); remedy != "" {
+				reason = remedy
+			}
+			toast = m.notifyError("Playback failed: " + reason)
 		} else if msg.positionSec > 0 {
-			m.status = "Saved progress at " + formatDuration(msg.positionSec)
+			toast = m.notify("Saved progress at " + formatDuration(msg.positionSec))
 		} else {
-			m.status = "Playback finished"
+			toast = m.notify("Playback finished")
 		}
 		if msg.itemID != "" {
 			m.syncItemState(msg.itemID, func(item *service.MediaItem) {
-				if item.UserData == nil {
-					item.UserData = &service.UserData{}
-				}
-				item.UserData.PlaybackPositionTicks = msg.positionSec * 10000000
+				setPlaybackPosition(item, msg.positionSec)
 			})
 		}
-		return m, nil
+		if restart := m.restartItem; restart != nil {
+			m.restartItem = nil
+			if msg.err == nil {
+				model, cmd := m.playItemAt(*restart, msg.positionSec, "Switched to direct play: "+restart.Name)
+				return model, tea.Batch(toast, cmd)
+			}
+		}
+		return m, toast
 
 	case favoriteMsg:
 		if msg.err != nil {
-			m.status = "Favorite error: " + msg.err.Error()
-			return m, nil
+			return m, m.notifyError("Favorite error: " + msg.err.Error())
 		}
 		delete(m.sectionCache, SectionFavorites)
 		m.syncItemState(msg.itemID, func(item *service.MediaItem) {
@@ -441,21 +849,154 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			item.UserData.IsFavorite = msg.isFav
 		})
+		toast := m.notify("Removed from favorites")
 		if msg.isFav {
-			m.status = "Added to favorites"
-		} else {
-			m.status = "Removed from favorites"
+			toast = m.notify("Added to favorites")
 		}
 		if m.section == SectionFavorites {
-			return m.refreshCurrentView()
+			model, cmd := m.refreshCurrentView()
+			return model, tea.Batch(toast, cmd)
+		}
+		return m, toast
+
+	case ratingMsg:
+		if msg.err != nil {
+			return m, m.notifyError("Rating error: " + msg.err.Error())
+		}
+		m.applyRating(msg.itemID, msg.likes)
+		switch {
+		case msg.likes == nil:
+			return m, m.notify("Rating cleared")
+		case *msg.likes:
+			return m, m.notify("Liked")
+		default:
+			return m, m.notify("Disliked")
+		}
+
+	case itemDetailMsg:
+		if msg.err != nil {
+			return m, m.notifyError("Failed to load details: " + msg.err.Error())
+		}
+		if m.detailItem != nil && msg.item != nil && m.detailItem.ID == msg.item.ID {
+			m.detailItem = msg.item
+		}
+		return m, nil
+
+	case similarMsg:
+		if m.detailItem != nil && m.detailItem.ID == msg.itemID && msg.err == nil {
+			m.similar = msg.items
+		}
+		return m, nil
+
+	case playedMsg:
+		if msg.err != nil {
+			return m, m.notifyError("Watched error: " + msg.err.Error())
+		}
+		delete(m.sectionCache, SectionResume)
+		m.syncItemState(msg.itemID, func(item *service.MediaItem) {
+			if item.UserData == nil {
+				item.UserData = &service.UserData{}
+			}
+			item.UserData.Played = msg.played
+			if msg.played {
+				item.UserData.PlaybackPositionTicks = 0
+				item.UserData.PlaybackPositionPct = 0
+			}
+		})
+		toast := m.notify("Marked as unplayed")
+		if msg.played {
+			toast = m.notify("Marked as played")
+		}
+		if m.section == SectionResume {
+			model, cmd := m.refreshCurrentView()
+			return model, tea.Batch(toast, cmd)
+		}
+		return m, toast
+
+	case downloadsVerifiedMsg:
+		m.status = ""
+		toast := m.notify(fmt.Sprintf("Verified %d of %d downloads", msg.verified, msg.total))
+		if m.view.mode == viewDownloads {
+			return m, tea.Batch(toast, m.loadDownloads())
 		}
+		return m, toast
+
+	case seasonPickerMsg:
+		m.applySeasonPicker(msg)
+		return m, nil
+
+	case kioskLibrariesMsg:
+		m.applyKioskLibraries(msg)
+		return m, nil
+
+	case scriptsLoadedMsg:
+		m.applyScriptsLoaded(msg)
+		return m, nil
+
+	case scriptDoneMsg:
+		m.applyScriptDone(msg)
+		return m, nil
+
+	case queuedMsg:
+		m.applyQueued(msg)
+		return m, nil
+
+	case exportedMsg:
+		m.applyExported(msg)
 		return m, nil
 
+	case guideMsg:
+		m.applyGuide(msg)
+		return m, nil
+
+	case sessionsMsg:
+		m.applySessions(msg)
+		return m, nil
+
+	case sessionActionMsg:
+		return m, m.applySessionAction(msg)
+
+	case transcodesMsg:
+		m.applyTranscodes(msg)
+		return m, nil
+
+	case auditMsg:
+		m.applyAudit(msg)
+		return m, nil
+
+	case bulkEpisodesMsg:
+		return m, m.applyBulkEpisodes(msg)
+
+	case bulkPlayedStepMsg:
+		return m, m.applyBulkPlayedStep(msg)
+
+	case directPlayMsg:
+		m.applyDirectPlay(msg)
+		return m, nil
+
+	case quickResumeMsg:
+		if msg.err != nil {
+			m.status = ""
+			return m, m.notifyError("Cannot resume: " + msg.err.Error())
+		}
+		return m.playItem(*msg.item, false)
+
+	case reportsSyncedMsg:
+		m.applyReportsSynced(msg)
+		return m, nil
+
+	case updateAvailableMsg:
+		m.latestVersion = string(msg)
+		return m, nil
+
+	case serviceEventMsg:
+		m.applyServiceEvent(service.Event(msg))
+		return m, waitForEvent(m.events)
+
 	case connectServerMsg:
 		if msg.err != nil {
-			m.status = "Connect failed: " + msg.err.Error()
 			m.state = StateServerManage
-			return m, nil
+			return m, m.notifyError("Connect failed: " + msg.err.Error())
 		}
 		m.resetForServerSwitch(msg.samePrefix)
 		return m, m.loadResume()
@@ -463,8 +1004,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case pingServersMsg:
 		m.pingInProgress = false
 		m.serverLatencies = msg.latencies
-		m.status = "Ping complete"
-		return m, nil
+		m.status = ""
+		return m, m.notify("Ping complete")
 	}
 
 	return m, nil
@@ -474,35 +1015,27 @@ func (m *Model) loadVisibleImages() tea.Cmd {
 	if len(m.items) == 0 || m.width <= 0 || m.height <= 0 {
 		return nil
 	}
-
-	var cmds []tea.Cmd
-	start := m.cursor - 2
-	end := m.cursor + 3
-
-	if start < 0 {
-		start = 0
-	}
-	if end > len(m.items) {
-		end = len(m.items)
+	if m.mini {
+		return nil
 	}
 
-	statusWidth := 32
-	if m.width < 100 {
-		statusWidth = 28
+	wanted := m.carouselCovers()
+	if m.gridMode {
+		wanted = m.gridCovers()
 	}
-	contentWidth := m.width - statusWidth
-	coverWidth, coverHeight := m.coverFrame(contentWidth, m.height)
-	if coverWidth <= 0 || coverHeight <= 0 {
-		return nil
+	keep := make(map[imageKey]bool, len(wanted)+1)
+	for _, c := range wanted {
+		keep[c.key] = true
 	}
-
-	for i := start; i < end; i++ {
-		item := m.items[i]
-		if _, ok := m.coverCache[item.ID]; !ok {
-			cmds = append(cmds, m.loadImage(item, coverWidth, coverHeight))
-		}
+	if _, track, ok := m.currentTrack(); ok {
+		keep[trackArtKey(track)] = true
+	}
+	m.images.Retain(keep)
+	for _, c := range wanted {
+		m.requestImage(c.key, c.item)
 	}
 
+	var cmds []tea.Cmd
 	if m.cursor < len(m.items) {
 		curItem := m.items[m.cursor]
 		if _, ok := m.detailCache[curItem.ID]; !ok {
@@ -523,6 +1056,63 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.state == StateServerEdit {
 		return m.handleServerEditKey(msg)
 	}
+	if m.state == StateSettings {
+		return m.handleSettingsKey(msg)
+	}
+	if m.state == StateDetail {
+		return m.handleDetailKey(msg)
+	}
+	if m.state == StateFilter {
+		return m.handleFilterKey(msg)
+	}
+	if m.state == StateLogPicker {
+		return m.handleLogPickerKey(msg)
+	}
+	if m.state == StateSeasonPicker {
+		return m.handleSeasonPickerKey(msg)
+	}
+	if m.state == StateKioskPicker {
+		return m.handleKioskPickerKey(msg)
+	}
+	if m.state == StateKioskPIN {
+		return m.handleKioskPINKey(msg)
+	}
+	if m.state == StateScriptPicker {
+		return m.handleScriptPickerKey(msg)
+	}
+	if m.state == StateQueue {
+		return m.handleQueueKey(msg)
+	}
+	if m.state == StateGuide {
+		return m.handleGuideKey(msg)
+	}
+	if m.state == StateSessions {
+		return m.handleSessionsKey(msg)
+	}
+	if m.state == StateTranscodes {
+		return m.handleTranscodesKey(msg)
+	}
+	if m.state == StateVersions {
+		return m.handleVersionsKey(msg)
+	}
+	if m.state == StateAudit {
+		return m.handleAuditKey(msg)
+	}
+	if m.state == StateBulkPlayed {
+		return m.handleBulkPlayedKey(msg)
+	}
+
+	if m.jumpPending {
+		return m.handleJumpKey(msg)
+	}
+
+	if cmd, ok := m.handleKioskKey(msg.String()); ok {
+		return m, cmd
+	}
+
+	if cmd, ok := m.handlePlaybackKey(msg.String()); ok {
+		return m, cmd
+	}
 
 	switch msg.String() {
 	case "q", "ctrl+c":
@@ -541,16 +1131,54 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "right", "l":
 		if m.cursor < len(m.items)-1 {
 			m.cursor++
-			return m, m.loadVisibleImages()
+			return m, tea.Batch(m.loadVisibleImages(), m.loadMore())
 		} else if (m.page+1)*m.pageSize < m.totalItems {
 			m.page++
 			m.state = StateLoading
 			return m, m.loadCurrentPagedSection()
 		}
 
+	case "up", "k":
+		if m.mini {
+			return m.moveCursor(-1)
+		}
+		if m.gridMode {
+			cols, _ := m.gridLayout(m.gridFrame())
+			return m.moveCursor(-cols)
+		}
+
+	case "down", "j":
+		if m.mini {
+			return m.moveCursor(1)
+		}
+		if m.gridMode {
+			cols, _ := m.gridLayout(m.gridFrame())
+			return m.moveCursor(cols)
+		}
+
+	case "t":
+		return m.openFilter()
+
+	case "v":
+		if m.mini {
+			return m, nil
+		}
+		m.gridMode = !m.gridMode
+		if m.gridMode {
+			m.status = "Grid view"
+		} else {
+			m.status = "Carousel view"
+		}
+		return m, m.loadVisibleImages()
+
 	case "enter":
 		return m.selectItem()
 
+	case "i":
+		if item, ok := m.currentItem(); ok {
+			return m.openDetail(item)
+		}
+
 	case "p":
 		if len(m.items) > 0 && m.cursor < len(m.items) {
 			item := m.items[m.cursor]
@@ -569,6 +1197,10 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "backspace", "esc":
+		if m.quickBase != nil {
+			m.clearQuickFilter()
+			return m, m.loadVisibleImages()
+		}
 		return m.goBack()
 
 	case "1":
@@ -580,6 +1212,99 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "3":
 		return m.switchSection(SectionHistory, func() tea.Cmd { return m.loadHistory(0) })
 
+	case "5":
+		return m.switchSection(SectionReleased, func() tea.Cmd { return m.loadReleased(0) })
+
+	case "6":
+		return m.switchSection(SectionStudios, func() tea.Cmd { return m.loadStudios(0) })
+
+	case "7":
+		return m.switchSection(SectionDownloads, m.loadDownloads)
+
+	case "8":
+		return m.switchSection(SectionAiring, func() tea.Cmd { return m.loadAiring(0) })
+
+	case "9":
+		return m.switchSection(SectionLiveTV, func() tea.Cmd { return m.loadChannels(0) })
+
+	case "N":
+		return m.switchSection(SectionLatest, m.loadLatest)
+
+	case "O":
+		if item, ok := m.currentItem(); ok && item.Playable {
+			return m.openVersions(item, -1, "Launching MPV: "+item.Name)
+		}
+
+	case "G":
+		return m.openGuide()
+
+	case "y":
+		return m.openGenres()
+
+	case "A":
+		return m.openSessions()
+
+	case "T":
+		return m.openTranscodes()
+
+	case "V":
+		if m.section != SectionDownloads {
+			return m, nil
+		}
+		m.status = "Verifying downloads..."
+		return m, m.verifyDownloads()
+
+	case "H":
+		if m.section != SectionHistory {
+			return m, nil
+		}
+		m.localHistory = !m.localHistory
+		m.page = 0
+		m.keepCursor = false
+		m.state = StateLoading
+		return m, m.loadHistory(0)
+
+	case "L":
+		if m.section != SectionLatest {
+			return m, nil
+		}
+		m.latestByLibrary = !m.latestByLibrary
+		m.keepCursor = false
+		m.state = StateLoading
+		return m, m.loadLatest()
+
+	case "K":
+		m.status = "Loading libraries..."
+		return m, m.openKioskPicker()
+
+	case "x":
+		if item, ok := m.currentItem(); ok {
+			return m, m.openScripts(item)
+		}
+
+	case "e":
+		if item, ok := m.currentItem(); ok {
+			return m, m.enqueue(item)
+		}
+
+	case "E":
+		return m.openQueue()
+
+	case "M":
+		if item, ok := m.currentItem(); ok {
+			return m, m.exportM3U(item)
+		}
+
+	case "z":
+		return m.playShuffle(false)
+
+	case "Z":
+		return m.playShuffle(true)
+
+	case "0":
+		m.status = "Resuming last watched..."
+		return m, m.quickResume()
+
 	case "4", "/":
 		m.state = StateSearching
 		m.searchInput.SetValue(m.lastSearchQuery)
@@ -591,6 +1316,29 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.toggleFavorite(item)
 		}
 
+	case "W":
+		return m.toggleHideWatched()
+
+	case "D":
+		if item, ok := m.currentItem(); ok {
+			return m.startDownload(item)
+		}
+
+	case "w":
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			item := m.items[m.cursor]
+			if item.Type == "Season" || item.Type == "Series" {
+				return m.openBulkPlayed(item)
+			}
+			return m, m.togglePlayed(item)
+		}
+
+	case "b":
+		if target, ok := m.bulkPlayedTarget(); ok {
+			return m.openBulkPlayed(target)
+		}
+		m.status = "Open a series or season to change its watched state"
+
 	case "c":
 		if len(m.items) > 0 && m.cursor < len(m.items) {
 			item := m.items[m.cursor]
@@ -600,14 +1348,12 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case ":":
+		return m, m.openCommand()
+
 	case "d":
-		m.loggingEnabled = !m.loggingEnabled
-		logging.SetEnabled(m.loggingEnabled)
-		if m.loggingEnabled {
-			m.status = "Debug logging: ON"
-		} else {
-			m.status = "Debug logging: OFF"
-		}
+		m.logCursor = 0
+		m.state = StateLogPicker
 		return m, nil
 
 	case "r":
@@ -624,6 +1370,15 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case "g":
+		if len(m.items) > 0 {
+			m.jumpPending = true
+			m.status = "Jump to: type a letter (enter: season picker)"
+		}
+
+	case "ctrl+f":
+		return m.openQuickFilter()
+
 	case "S":
 		if len(m.items) > 0 && m.cursor < len(m.items) {
 			item := m.items[m.cursor]
@@ -639,6 +1394,11 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = StateServerManage
 		m.serverCursor = m.svc.Store().GetActiveServerIndex()
 		return m, nil
+
+	case "o":
+		m.state = StateSettings
+		m.settingsCursor = 0
+		return m, nil
 	}
 
 	return m, nil
@@ -651,18 +1411,12 @@ func (m *Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.searchInput.Blur()
 		return m, nil
 
+	case "tab":
+		m.deepSearch = !m.deepSearch
+		return m, nil
+
 	case "enter":
-		m.lastSearchQuery = strings.TrimSpace(m.searchInput.Value())
-		if m.lastSearchQuery == "" {
-			m.status = "Enter keyword to search"
-			return m, nil
-		}
-		m.page = 0
-		m.state = StateLoading
-		m.section = SectionSearch
-		m.view = viewState{mode: viewSearch}
-		m.searchInput.Blur()
-		return m, m.searchItems()
+		return m.runSearch(m.searchInput.Value())
 	}
 
 	var cmd tea.Cmd
@@ -670,10 +1424,47 @@ func (m *Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m *Model) runSearch(query string) (tea.Model, tea.Cmd) {
+	m.lastSearchQuery = strings.TrimSpace(query)
+	if m.lastSearchQuery == "" {
+		m.status = "Enter keyword to search"
+		return m, nil
+	}
+	m.page = 0
+	m.state = StateLoading
+	m.section = SectionSearch
+	m.view = viewState{mode: viewSearch}
+	m.searchInput.Blur()
+	return m, m.searchItems()
+}
+
 func (m *Model) hasSearchCriteria() bool {
 	return strings.TrimSpace(m.lastSearchQuery) != ""
 }
 
+func (m *Model) activateServer(index int) tea.Cmd {
+	oldPrefix := ""
+	if srv := m.svc.GetActiveServer(); srv != nil {
+		oldPrefix = srv.Prefix
+	}
+
+	err := m.svc.ActivateServer(index)
+	if err != nil {
+		return func() tea.Msg {
+			return connectServerMsg{err: err}
+		}
+	}
+
+	newPrefix := ""
+	if srv := m.svc.GetActiveServer(); srv != nil {
+		newPrefix = srv.Prefix
+	}
+
+	return func() tea.Msg {
+		return connectServerMsg{err: nil, samePrefix: oldPrefix != "" && oldPrefix == newPrefix}
+	}
+}
+
 func (m *Model) handleServerManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	servers := m.svc.GetServers()
 
@@ -694,26 +1485,7 @@ func (m *Model) handleServerManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "enter":
 		if len(servers) > 0 && m.serverCursor < len(servers) {
-			oldPrefix := ""
-			if srv := m.svc.GetActiveServer(); srv != nil {
-				oldPrefix = srv.Prefix
-			}
-
-			err := m.svc.ActivateServer(m.serverCursor)
-			if err != nil {
-				return m, func() tea.Msg {
-					return connectServerMsg{err: err}
-				}
-			}
-
-			newPrefix := ""
-			if srv := m.svc.GetActiveServer(); srv != nil {
-				newPrefix = srv.Prefix
-			}
-
-			return m, func() tea.Msg {
-				return connectServerMsg{err: nil, samePrefix: oldPrefix != "" && oldPrefix == newPrefix}
-			}
+			return m, m.activateServer(m.serverCursor)
 		}
 
 	case "a":
@@ -776,8 +1548,8 @@ func (m *Model) handleServerEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		password := m.serverInputs[3].Value()
 
-		if srv.URL == "" {
-			m.status = "URL is required"
+		if _, err := service.NormalizeServerURL(srv.URL); err != nil {
+			m.status = err.Error()
 			return m, nil
 		}
 
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"ember/internal/storage"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const auditVisible = 15
+
+type auditMsg struct {
+	entries []storage.AuditEntry
+	err     error
+}
+
+func (m *Model) loadAudit() tea.Cmd {
+	query := m.auditQuery.Value()
+	return func() tea.Msg {
+		entries, err := m.svc.AuditLog(query)
+		return auditMsg{entries: entries, err: err}
+	}
+}
+
+func (m *Model) openAudit() (tea.Model, tea.Cmd) {
+	m.auditQuery = textinput.New()
+	m.auditQuery.Prompt = "/ "
+	m.auditQuery.Placeholder = "user, action, server or title"
+	m.auditQuery.CharLimit = 60
+	m.auditQuery.Width = 40
+	m.auditEntries = nil
+	m.auditCursor = 0
+	m.state = StateAudit
+	return m, m.loadAudit()
+}
+
+func (m *Model) applyAudit(msg auditMsg) {
+	if msg.err != nil {
+		m.status = "Cannot read audit log: " + msg.err.Error()
+		return
+	}
+	m.auditEntries = msg.entries
+	m.auditCursor = min(m.auditCursor, max(len(m.auditEntries)-1, 0))
+}
+
+func (m *Model) handleAuditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.auditQuery.Focused() {
+		switch msg.String() {
+		case "esc", "enter":
+			m.auditQuery.Blur()
+			return m, nil
+		}
+		before := m.auditQuery.Value()
+		var cmd tea.Cmd
+		m.auditQuery, cmd = m.auditQuery.Update(msg)
+		if m.auditQuery.Value() != before {
+			m.auditCursor = 0
+			return m, tea.Batch(cmd, m.loadAudit())
+		}
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateLogPicker
+		return m, nil
+
+	case "/":
+		return m, m.auditQuery.Focus()
+
+	case "r":
+		return m, m.loadAudit()
+
+	case "up", "k":
+		if m.auditCursor > 0 {
+			m.auditCursor--
+		}
+
+	case "down", "j":
+		if m.auditCursor < len(m.auditEntries)-1 {
+			m.auditCursor++
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) renderAudit() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render(fmt.Sprintf("Audit Trail (%d)", len(m.auditEntries)))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	actionStyle := lipgloss.NewStyle().Width(16)
+
+	lines := []string{m.auditQuery.View(), ""}
+	start, end := visibleRange(m.auditCursor, len(m.auditEntries), auditVisible)
+	for i := start; i < end; i++ {
+		entry := m.auditEntries[i]
+		style := dimStyle
+		prefix := "  "
+		if i == m.auditCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+			prefix = "> "
+		}
+		when := entry.Time
+		if t, err := time.Parse(time.RFC3339, entry.Time); err == nil {
+			when = t.Local().Format("2006-01-02 15:04")
+		}
+		who := entry.User
+		if entry.Server != "" {
+			who += "@" + entry.Server
+		}
+		line := fmt.Sprintf("%s  %s  %s%s", when, truncateText(who, 24), actionStyle.Render(entry.Action), truncateText(entry.Subject, 40))
+		lines = append(lines, style.Render(prefix+line))
+	}
+	if len(m.auditEntries) == 0 {
+		lines = append(lines, dimStyle.Render("No matching audit entries"))
+	}
+
+	hint := dimStyle.MarginTop(1).Render("[/] search  [↑↓] select  [r] refresh  [esc] back")
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
@@ -0,0 +1,378 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const commandHistoryLimit = 50
+
+type command struct {
+	name  string
+	usage string
+	args  func(m *Model) []string
+	run   func(m *Model, arg string) (tea.Model, tea.Cmd)
+}
+
+var sectionCommands = []struct {
+	name string
+	key  string
+}{
+	{"continue", "1"},
+	{"favorites", "2"},
+	{"history", "3"},
+	{"released", "5"},
+	{"studios", "6"},
+	{"downloads", "7"},
+	{"airing", "8"},
+	{"livetv", "9"},
+	{"latest", "N"},
+}
+
+var commands = []command{
+	{name: "search", usage: "search <query>", run: (*Model).commandSearch},
+	{name: "server", usage: "server <number|name>", args: serverNames, run: (*Model).commandServer},
+	{name: "page", usage: "page <number>", run: (*Model).commandPage},
+	{name: "filter", usage: "filter unwatched|all", args: staticArgs("unwatched", "all"), run: (*Model).commandFilter},
+	{name: "sort", usage: "sort " + strings.Join(service.ItemSorts, "|") + " [desc]", args: staticArgs(service.ItemSorts...), run: (*Model).commandSort},
+	{name: "section", usage: "section <name>", args: sectionNames, run: (*Model).commandSection},
+	{name: "quit", usage: "quit", run: (*Model).commandQuit},
+}
+
+func staticArgs(values ...string) func(*Model) []string {
+	return func(*Model) []string { return values }
+}
+
+func serverNames(m *Model) []string {
+	var names []string
+	for _, srv := range m.svc.GetServers() {
+		names = append(names, srv.Name)
+	}
+	return names
+}
+
+func sectionNames(*Model) []string {
+	var names []string
+	for _, s := range sectionCommands {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// lookupCommand accepts a full command name or any unambiguous prefix.
+func lookupCommand(name string) (command, error) {
+	var found []command
+	for _, c := range commands {
+		if c.name == name {
+			return c, nil
+		}
+		if strings.HasPrefix(c.name, name) {
+			found = append(found, c)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return command{}, fmt.Errorf("unknown command: %s", name)
+	case 1:
+		return found[0], nil
+	}
+	var names []string
+	for _, c := range found {
+		names = append(names, c.name)
+	}
+	return command{}, fmt.Errorf("ambiguous command %s: %s", name, strings.Join(names, ", "))
+}
+
+func (m *Model) openCommand() tea.Cmd {
+	m.command = textinput.New()
+	m.command.Prompt = ":"
+	m.command.CharLimit = 100
+	m.command.Width = max(m.width-4, 10)
+	m.commandRecall = len(m.commandHistory)
+	m.commandChoice = -1
+	return m.command.Focus()
+}
+
+func (m *Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key != "tab" && key != "shift+tab" {
+		m.commandChoice = -1
+	}
+
+	switch key {
+	case "esc", "ctrl+c":
+		m.command.Blur()
+		return m, nil
+
+	case "backspace":
+		if m.command.Value() == "" {
+			m.command.Blur()
+			return m, nil
+		}
+
+	case "tab", "shift+tab":
+		m.completeCommand(key == "shift+tab")
+		return m, nil
+
+	case "up":
+		if m.commandRecall > 0 {
+			m.commandRecall--
+			m.command.SetValue(m.commandHistory[m.commandRecall])
+			m.command.CursorEnd()
+		}
+		return m, nil
+
+	case "down":
+		if m.commandRecall < len(m.commandHistory) {
+			m.commandRecall++
+			value := ""
+			if m.commandRecall < len(m.commandHistory) {
+				value = m.commandHistory[m.commandRecall]
+			}
+			m.command.SetValue(value)
+			m.command.CursorEnd()
+		}
+		return m, nil
+
+	case "enter":
+		line := strings.TrimSpace(m.command.Value())
+		m.command.Blur()
+		if line == "" {
+			return m, nil
+		}
+		if n := len(m.commandHistory); n == 0 || m.commandHistory[n-1] != line {
+			m.commandHistory = append(m.commandHistory, line)
+			if len(m.commandHistory) > commandHistoryLimit {
+				m.commandHistory = m.commandHistory[1:]
+			}
+		}
+		return m.runCommand(line)
+	}
+
+	var cmd tea.Cmd
+	m.command, cmd = m.command.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) runCommand(line string) (tea.Model, tea.Cmd) {
+	name, arg, _ := strings.Cut(line, " ")
+	c, err := lookupCommand(strings.ToLower(name))
+	if err != nil {
+		m.status = err.Error()
+		return m, nil
+	}
+	return c.run(m, strings.TrimSpace(arg))
+}
+
+// commandCompletions returns the candidates for the last word of typed:
+// command names for the first word, the command's arguments after it.
+func (m *Model) commandCompletions(typed string) (string, []string) {
+	name, arg, hasArg := strings.Cut(typed, " ")
+	if !hasArg {
+		var names []string
+		for _, c := range commands {
+			if strings.HasPrefix(c.name, strings.ToLower(name)) {
+				names = append(names, c.name)
+			}
+		}
+		return "", names
+	}
+
+	c, err := lookupCommand(strings.ToLower(name))
+	if err != nil || c.args == nil {
+		return "", nil
+	}
+	var matches []string
+	for _, candidate := range c.args(m) {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(strings.TrimSpace(arg))) {
+			matches = append(matches, candidate)
+		}
+	}
+	return c.name + " ", matches
+}
+
+func (m *Model) completeCommand(backwards bool) {
+	if m.commandChoice < 0 {
+		m.commandTyped = m.command.Value()
+	}
+	prefix, matches := m.commandCompletions(m.commandTyped)
+	if len(matches) == 0 {
+		return
+	}
+	switch {
+	case m.commandChoice < 0 && backwards:
+		m.commandChoice = len(matches) - 1
+	case m.commandChoice < 0:
+		m.commandChoice = 0
+	case backwards:
+		m.commandChoice = (m.commandChoice - 1 + len(matches)) % len(matches)
+	default:
+		m.commandChoice = (m.commandChoice + 1) % len(matches)
+	}
+	value := prefix + matches[m.commandChoice]
+	if len(matches) == 1 && prefix == "" {
+		value += " "
+	}
+	m.command.SetValue(value)
+	m.command.CursorEnd()
+}
+
+// overlayInputLine draws the command line and its completions, or the
+// quick filter, over the bottom rows of the screen, vim-style, so the
+// layout underneath keeps its size and cached covers stay valid.
+func (m *Model) overlayInputLine(view string) string {
+	if m.quickFilter.Focused() {
+		return m.overlayRows(view, m.quickFilterLine())
+	}
+	if !m.command.Focused() {
+		return view
+	}
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	typed := m.command.Value()
+	if m.commandChoice >= 0 {
+		typed = m.commandTyped
+	}
+	_, matches := m.commandCompletions(typed)
+	var hint string
+	if len(matches) > 0 {
+		parts := make([]string, len(matches))
+		for i, match := range matches {
+			parts[i] = dimStyle.Render(match)
+			if i == m.commandChoice {
+				parts[i] = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")).Render(match)
+			}
+		}
+		hint = strings.Join(parts, "  ")
+	} else if name, _, hasArg := strings.Cut(typed, " "); hasArg {
+		if c, err := lookupCommand(strings.ToLower(name)); err == nil {
+			hint = dimStyle.Render(c.usage)
+		}
+	}
+
+	return m.overlayRows(view, " "+hint, m.command.View())
+}
+
+func (m *Model) overlayRows(view string, rows ...string) string {
+	lineStyle := lipgloss.NewStyle().Width(m.width).MaxWidth(m.width).Background(lipgloss.Color("235"))
+	for i, row := range rows {
+		rows[i] = lineStyle.Render(row)
+	}
+	lines := strings.Split(view, "\n")
+	if len(lines) < len(rows) {
+		return strings.Join(rows, "\n")
+	}
+	return strings.Join(append(lines[:len(lines)-len(rows)], rows...), "\n")
+}
+
+func (m *Model) commandQuit(string) (tea.Model, tea.Cmd) {
+	return m, tea.Quit
+}
+
+func (m *Model) commandSearch(arg string) (tea.Model, tea.Cmd) {
+	if arg == "" {
+		arg = m.lastSearchQuery
+	}
+	return m.runSearch(arg)
+}
+
+func (m *Model) commandServer(arg string) (tea.Model, tea.Cmd) {
+	if arg == "" {
+		m.status = "Usage: :server <number|name>"
+		return m, nil
+	}
+	servers := m.svc.GetServers()
+	index := -1
+	if n, err := strconv.Atoi(arg); err == nil {
+		index = n - 1
+	} else {
+		for i, srv := range servers {
+			if strings.EqualFold(srv.Name, arg) {
+				index = i
+				break
+			}
+		}
+	}
+	if index < 0 || index >= len(servers) {
+		m.status = "No such server: " + arg
+		return m, nil
+	}
+	if servers[index].IsActive {
+		m.status = "Already connected to " + servers[index].Name
+		return m, nil
+	}
+	m.status = "Switching to " + servers[index].Name + "..."
+	return m, m.activateServer(index)
+}
+
+func (m *Model) commandPage(arg string) (tea.Model, tea.Cmd) {
+	pages := (m.totalItems + m.pageSize - 1) / m.pageSize
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		m.status = "Usage: :page <number>"
+		return m, nil
+	}
+	if pages <= 1 || m.moreAfter != "" {
+		m.status = "This view has no pages"
+		return m, nil
+	}
+	if n > pages {
+		m.status = fmt.Sprintf("Only %d pages", pages)
+		return m, nil
+	}
+	m.page = n - 1
+	m.cursor = 0
+	m.keepCursor = false
+	m.state = StateLoading
+	return m, m.loadCurrentPagedSection()
+}
+
+func (m *Model) commandFilter(arg string) (tea.Model, tea.Cmd) {
+	if m.view.mode != viewItems {
+		m.status = "Filters are available in library views"
+		return m, nil
+	}
+	switch strings.ToLower(arg) {
+	case "unwatched":
+		m.itemFilter.Unwatched = true
+	case "all", "":
+		m.itemFilter.Unwatched = false
+	default:
+		m.status = "Usage: :filter unwatched|all"
+		return m, nil
+	}
+	return m.reloadFiltered()
+}
+
+func (m *Model) commandSort(arg string) (tea.Model, tea.Cmd) {
+	if m.view.mode != viewItems {
+		m.status = "Sorting is available in library views"
+		return m, nil
+	}
+	fields := strings.Fields(strings.ToLower(arg))
+	if len(fields) == 0 || !slices.Contains(service.ItemSorts, fields[0]) {
+		m.status = "Usage: :sort " + strings.Join(service.ItemSorts, "|") + " [desc]"
+		return m, nil
+	}
+	m.itemFilter.Sort = fields[0]
+	m.itemFilter.Descending = len(fields) > 1 && fields[1] == "desc"
+	return m.reloadFiltered()
+}
+
+func (m *Model) commandSection(arg string) (tea.Model, tea.Cmd) {
+	for _, s := range sectionCommands {
+		if strings.HasPrefix(s.name, strings.ToLower(arg)) && arg != "" {
+			return m.handleKey(keyMsg(s.key))
+		}
+	}
+	m.status = "Unknown section: " + arg
+	return m, nil
+}
@@ -0,0 +1,293 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type itemDetailMsg struct {
+	item *service.MediaItem
+	err  error
+}
+
+type similarMsg struct {
+	itemID string
+	items  []service.MediaItem
+	err    error
+}
+
+const similarLimit = 12
+
+type ratingMsg struct {
+	itemID string
+	likes  *bool
+	err    error
+}
+
+func (m *Model) rateItem(item service.MediaItem, like bool) tea.Cmd {
+	target := &like
+	if item.UserData != nil && item.UserData.Likes != nil && *item.UserData.Likes == like {
+		target = nil
+	}
+
+	return func() tea.Msg {
+		result, err := m.svc.SetRating(item.ID, target)
+		if err != nil {
+			return ratingMsg{itemID: item.ID, err: err}
+		}
+		return ratingMsg{itemID: item.ID, likes: result.Likes}
+	}
+}
+
+func (m *Model) applyRating(itemID string, likes *bool) {
+	update := func(item *service.MediaItem) {
+		if item.UserData == nil {
+			item.UserData = &service.UserData{}
+		}
+		item.UserData.Likes = likes
+	}
+	m.syncItemState(itemID, update)
+	if m.detailItem != nil && m.detailItem.ID == itemID {
+		update(m.detailItem)
+	}
+}
+
+func (m *Model) openDetail(item service.MediaItem) (tea.Model, tea.Cmd) {
+	m.state = StateDetail
+	m.detailItem = &item
+	m.detailCursor = 0
+	m.similar = nil
+	m.similarIndex = -1
+	return m, tea.Batch(func() tea.Msg {
+		full, err := m.svc.GetItem(item.ID)
+		return itemDetailMsg{item: full, err: err}
+	}, m.loadSimilar(item))
+}
+
+func (m *Model) loadSimilar(item service.MediaItem) tea.Cmd {
+	switch item.Type {
+	case "Movie", "Series", "Season", "Episode", "MusicAlbum":
+	default:
+		return nil
+	}
+	return func() tea.Msg {
+		items, err := m.svc.GetSimilar(item, similarLimit)
+		return similarMsg{itemID: item.ID, items: items, err: err}
+	}
+}
+
+func (m *Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.detailItem == nil {
+		m.state = StateBrowsing
+		return m, nil
+	}
+	item := *m.detailItem
+	chapters := item.Chapters
+
+	switch msg.String() {
+	case "q", "esc", "backspace", "i":
+		m.state = StateBrowsing
+		m.detailItem = nil
+		m.similar = nil
+		return m, nil
+
+	case "left", "h":
+		if m.similarIndex >= 0 {
+			m.similarIndex--
+		}
+
+	case "right", "l":
+		if m.similarIndex < len(m.similar)-1 {
+			m.similarIndex++
+		}
+
+	case "up", "k":
+		if m.detailCursor > 0 {
+			m.detailCursor--
+		}
+
+	case "down", "j":
+		if m.detailCursor < len(chapters)-1 {
+			m.detailCursor++
+		}
+
+	case "enter":
+		if m.similarIndex >= 0 && m.similarIndex < len(m.similar) {
+			return m.openDetail(m.similar[m.similarIndex])
+		}
+		if !item.Playable {
+			return m, nil
+		}
+		m.state = StateBrowsing
+		if m.detailCursor < len(chapters) {
+			ch := chapters[m.detailCursor]
+			return m.playItemAt(item, ch.StartSec, fmt.Sprintf("Launching MPV at %s: %s", formatDuration(ch.StartSec), item.Name))
+		}
+		return m.playItem(item, false)
+
+	case "p":
+		if item.Playable {
+			m.state = StateBrowsing
+			return m.playItem(item, false)
+		}
+
+	case "+":
+		return m, m.rateItem(item, true)
+
+	case "-":
+		return m, m.rateItem(item, false)
+	}
+
+	return m, nil
+}
+
+func (m *Model) renderDetail(width, height int) string {
+	if m.detailItem == nil {
+		return ""
+	}
+	item := *m.detailItem
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("117"))
+
+	title := item.Name
+	if item.Year > 0 {
+		title = fmt.Sprintf("%s (%d)", item.Name, item.Year)
+	}
+	if context := itemContext(item); context != "" {
+		title = title + "  /  " + context
+	}
+
+	lines := []string{
+		titleStyle.Render(truncateText(title, width)),
+		dimStyle.Render(truncateText(strings.Join(itemMeta(item), "  "), width)),
+	}
+	if date := formatPremiereDate(item.NextAirDate); date != "" {
+		lines = append(lines, dimStyle.Render(truncateText("Next episode "+date+": "+item.NextEpisode, width)))
+	} else if item.Status == "Ended" {
+		lines = append(lines, dimStyle.Render("Series has ended"))
+	}
+	if history := formatPlaybackHistory(m.svc.PlaybackHistory(item)); history != "" {
+		lines = append(lines, dimStyle.Render(truncateText(history, width)))
+	}
+
+	if overview := strings.TrimSpace(item.Overview); overview != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("252")).Render(overview))
+	}
+
+	if len(item.Chapters) > 0 {
+		lines = append(lines, "", headerStyle.Render("Chapters"))
+		start, end := visibleRange(m.detailCursor, len(item.Chapters), max(3, height-len(lines)-4))
+		for i := start; i < end; i++ {
+			ch := item.Chapters[i]
+			name := strings.TrimSpace(ch.Name)
+			if name == "" {
+				name = fmt.Sprintf("Chapter %d", i+1)
+			}
+			line := fmt.Sprintf("%8s  %s", formatDuration(ch.StartSec), name)
+			style := dimStyle
+			if i == m.detailCursor {
+				style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+				line = "> " + line
+			} else {
+				line = "  " + line
+			}
+			lines = append(lines, style.Render(truncateText(line, width)))
+		}
+	}
+
+	if len(m.similar) > 0 {
+		lines = append(lines, "", headerStyle.Render("More like this"))
+		lines = append(lines, m.renderSimilarRow(width))
+	}
+
+	hint := "[+/-] like/dislike  [esc] back"
+	if item.Playable {
+		hint = "[↑↓] chapter  [enter] play from chapter  [p] resume  [+/-] like/dislike  [esc] back"
+		if len(item.Chapters) == 0 {
+			hint = "[p/enter] play  [+/-] like/dislike  [esc] back"
+		}
+	}
+	if m.similarIndex >= 0 {
+		hint = "[←→] more like this  [enter] open  [esc] back"
+	} else if len(m.similar) > 0 {
+		hint = "[←→] more like this  " + hint
+	}
+	lines = append(lines, "", dimStyle.Render(hint))
+
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func (m *Model) renderSimilarRow(width int) string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+
+	const visible = 4
+	cellWidth := max((width-4)/visible-5, 8)
+	start, end := visibleRange(max(m.similarIndex, 0), len(m.similar), visible)
+	var cells []string
+	for i := start; i < end; i++ {
+		item := m.similar[i]
+		name := item.Name
+		if item.Year > 0 {
+			name = fmt.Sprintf("%s (%d)", item.Name, item.Year)
+		}
+		style := dimStyle
+		if i == m.similarIndex {
+			style = selectedStyle
+		}
+		cells = append(cells, style.Render(truncateText(name, cellWidth)))
+	}
+	row := strings.Join(cells, dimStyle.Render("  ·  "))
+	if start > 0 {
+		row = dimStyle.Render("‹ ") + row
+	}
+	if end < len(m.similar) {
+		row += dimStyle.Render(" ›")
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(row)
+}
+
+func formatPlaybackHistory(h service.PlaybackHistory) string {
+	var parts []string
+	if !h.LastPlayed.IsZero() {
+		parts = append(parts, "last watched on "+h.LastPlayed.Format("Jan 2, 2006"))
+	}
+	if h.PositionSec > 0 {
+		parts = append(parts, "stopped at "+formatDuration(h.PositionSec))
+	}
+	switch {
+	case h.PlayCount == 1:
+		parts = append(parts, "watched once")
+	case h.PlayCount > 1:
+		parts = append(parts, fmt.Sprintf("watched %d times", h.PlayCount))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	text := strings.Join(parts, ", ")
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+func visibleRange(cursor, total, size int) (int, int) {
+	if total <= size {
+		return 0, total
+	}
+	start := cursor - size/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + size
+	if end > total {
+		end = total
+		start = end - size
+	}
+	return start, end
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"ember/internal/logging"
+	"ember/internal/storage"
+)
+
+const (
+	diskCacheMaxBytes = 256 << 20
+
+	// diskCacheTrimTo is the fraction of diskCacheMaxBytes left after an
+	// eviction pass, so one new poster doesn't trigger a directory walk.
+	diskCacheTrimTo = 0.8
+
+	// diskCacheUntaggedTTL bounds how long a picture whose URL carries no
+	// image tag is reused; nothing else tells when it changed on the server.
+	diskCacheUntaggedTTL = 7 * 24 * time.Hour
+)
+
+var coverFiles = newDiskCache(coverCacheDir(), diskCacheMaxBytes)
+
+// coverCacheDir is ~/.ember/cache/images, or empty when the home
+// directory is unknown and nothing should be written.
+func coverCacheDir() string {
+	if storage.Dir() == "" {
+		return ""
+	}
+	return filepath.Join(storage.Dir(), "cache", "images")
+}
+
+// diskCache keeps downloaded picture bytes on disk, one file per URL.
+// Reads of tagged URLs refresh a file's modification time, untagged ones
+// expire diskCacheUntaggedTTL after download, and eviction removes the
+// oldest files once the directory grows past maxBytes.
+type diskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu     sync.Mutex
+	loaded bool
+	size   int64
+}
+
+func newDiskCache(dir string, maxBytes int64) *diskCache {
+	return &diskCache{dir: dir, maxBytes: maxBytes}
+}
+
+// path names the file of rawURL after its hash and reports whether the
+// URL carries an image tag. The access token is left out so a new login
+// still finds the pictures cached under the old one.
+func (c *diskCache) path(rawURL string) (string, bool) {
+	name, tagged := rawURL, false
+	if u, err := url.Parse(rawURL); err == nil {
+		query := u.Query()
+		query.Del("api_key")
+		tagged = query.Get("tag") != ""
+		u.RawQuery = query.Encode()
+		name = u.String()
+	}
+	sum := sha256.Sum256([]byte(name))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])), tagged
+}
+
+// Get returns the cached bytes of rawURL, if any.
+func (c *diskCache) Get(rawURL string) ([]byte, bool) {
+	if c.dir == "" {
+		return nil, false
+	}
+	path, tagged := c.path(rawURL)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if !tagged && time.Since(info.ModTime()) > diskCacheUntaggedTTL {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	if tagged {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+	}
+	return data, true
+}
+
+// Put stores data for rawURL and evicts old files if the cache is full.
+// Failures only cost a later re-download, so they are logged and dropped.
+func (c *diskCache) Put(rawURL string, data []byte) {
+	if c.dir == "" || len(data) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		logging.Images("Image disk cache unavailable", "dir", c.dir, "error", err)
+		return
+	}
+	c.load()
+
+	path, _ := c.path(rawURL)
+	var previous int64
+	if info, err := os.Stat(path); err == nil {
+		previous = info.Size()
+	}
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		logging.Images("Image disk cache write failed", "error", err)
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		logging.Images("Image disk cache write failed", "error", err)
+		return
+	}
+
+	c.size += int64(len(data)) - previous
+	if c.size > c.maxBytes {
+		c.evict()
+	}
+}
+
+// load sums the sizes of the files already on disk. It must be called
+// with c.mu held.
+func (c *diskCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && info.Mode().IsRegular() {
+			c.size += info.Size()
+		}
+	}
+}
+
+// evict removes the least recently used files until the cache is back
+// under its trim target. It must be called with c.mu held.
+func (c *diskCache) evict() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	files := make([]os.FileInfo, 0, len(entries))
+	var total int64
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && info.Mode().IsRegular() {
+			files = append(files, info)
+			total += info.Size()
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	target := int64(float64(c.maxBytes) * diskCacheTrimTo)
+	removed := 0
+	for _, info := range files {
+		if total <= target {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, info.Name())); err != nil {
+			continue
+		}
+		total -= info.Size()
+		removed++
+	}
+	c.size = total
+	logging.Images("Image disk cache evicted", "removed", removed, "bytes", total, "limit", c.maxBytes)
+}
@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type downloadTask struct {
+	name    string
+	written atomic.Int64
+	total   atomic.Int64
+}
+
+type downloadDoneMsg struct {
+	itemID string
+	name   string
+	path   string
+	err    error
+}
+
+type downloadTickMsg struct{}
+
+type bulkDownloadMsg struct {
+	name     string
+	episodes []service.MediaItem
+	err      error
+}
+
+type downloadsVerifiedMsg struct {
+	verified int
+	total    int
+}
+
+func (m *Model) startDownload(item service.MediaItem) (tea.Model, tea.Cmd) {
+	if item.Type == "Season" || item.Type == "Series" {
+		m.status = "Listing episodes of " + item.Name + "..."
+		return m, func() tea.Msg {
+			episodes, err := m.svc.EpisodesToDownload(item)
+			return bulkDownloadMsg{name: item.Name, episodes: episodes, err: err}
+		}
+	}
+	if !item.Playable {
+		m.status = "Only playable items can be downloaded"
+		return m, nil
+	}
+	if _, ok := m.svc.LocalPath(item.ID); ok {
+		m.status = "Already downloaded: " + item.Name
+		return m, nil
+	}
+	if _, ok := m.downloads[item.ID]; ok {
+		m.status = "Already downloading: " + item.Name
+		return m, nil
+	}
+
+	task := &downloadTask{name: item.Name}
+	if m.downloads == nil {
+		m.downloads = make(map[string]*downloadTask)
+	}
+	m.downloads[item.ID] = task
+	m.status = "Downloading " + item.Name
+
+	cmd := func() tea.Msg {
+		d, err := m.svc.Download(context.Background(), item.ID, func(written, total int64) {
+			task.written.Store(written)
+			task.total.Store(total)
+		})
+		if err != nil {
+			return downloadDoneMsg{itemID: item.ID, name: item.Name, err: err}
+		}
+		return downloadDoneMsg{itemID: item.ID, name: item.Name, path: d.Path}
+	}
+
+	if len(m.downloads) == 1 {
+		return m, tea.Batch(cmd, tickDownloads())
+	}
+	return m, cmd
+}
+
+func (m *Model) applyBulkDownload(msg bulkDownloadMsg) tea.Cmd {
+	if msg.err != nil {
+		m.status = "Download failed: " + msg.err.Error()
+		return nil
+	}
+
+	queued := make(map[string]bool, len(m.pendingDownloads))
+	for _, item := range m.pendingDownloads {
+		queued[item.ID] = true
+	}
+	added := 0
+	for _, ep := range msg.episodes {
+		if _, ok := m.downloads[ep.ID]; ok || queued[ep.ID] {
+			continue
+		}
+		m.pendingDownloads = append(m.pendingDownloads, ep)
+		added++
+	}
+	if added == 0 {
+		m.status = "Every episode of " + msg.name + " is already downloaded or queued"
+		return nil
+	}
+
+	m.status = fmt.Sprintf("Queued %d episode(s) of %s for download", added, msg.name)
+	return m.nextPendingDownload()
+}
+
+func (m *Model) nextPendingDownload() tea.Cmd {
+	for m.bulkDownloadID == "" && len(m.pendingDownloads) > 0 {
+		item := m.pendingDownloads[0]
+		m.pendingDownloads = m.pendingDownloads[1:]
+		if _, ok := m.downloads[item.ID]; ok {
+			continue
+		}
+		// Items that start nothing (already on disk, not playable) never
+		// send a downloadDoneMsg, so move on instead of waiting for one.
+		if _, cmd := m.startDownload(item); cmd != nil {
+			m.bulkDownloadID = item.ID
+			return cmd
+		}
+	}
+	return nil
+}
+
+func tickDownloads() tea.Cmd {
+	return tea.Tick(500*time.Millisecond, func(time.Time) tea.Msg {
+		return downloadTickMsg{}
+	})
+}
+
+func (m *Model) downloadStatus() string {
+	var current *downloadTask
+	for _, task := range m.downloads {
+		if current == nil || task.name < current.name {
+			current = task
+		}
+	}
+	if current == nil {
+		return ""
+	}
+
+	text := "Downloading " + current.name
+	if total := current.total.Load(); total > 0 {
+		text += fmt.Sprintf(" %d%%", current.written.Load()*100/total)
+	}
+	if n := len(m.downloads) + len(m.pendingDownloads); n > 1 {
+		text += fmt.Sprintf(" (+%d more)", n-1)
+	}
+	return text
+}
+
+func (m *Model) verifyDownloads() tea.Cmd {
+	return func() tea.Msg {
+		verified, total := m.svc.VerifyDownloads()
+		return downloadsVerifiedMsg{verified: verified, total: total}
+	}
+}
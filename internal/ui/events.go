@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"ember/internal/logging"
+	"ember/internal/service"
+	"ember/internal/update"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type serviceEventMsg service.Event
+
+func waitForEvent(events <-chan service.Event) tea.Cmd {
+	if events == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return nil
+		}
+		return serviceEventMsg(event)
+	}
+}
+
+func (m *Model) applyServiceEvent(event service.Event) {
+	logging.UI("Service event", "type", event.Type, "item", event.ItemID)
+
+	switch event.Type {
+	case service.EventFavoriteChanged:
+		delete(m.sectionCache, SectionFavorites)
+		m.syncItemState(event.ItemID, func(item *service.MediaItem) {
+			if item.UserData == nil {
+				item.UserData = &service.UserData{}
+			}
+			item.UserData.IsFavorite = event.Favorite
+		})
+
+	case service.EventPlayedChanged:
+		delete(m.sectionCache, SectionResume)
+		m.syncItemState(event.ItemID, func(item *service.MediaItem) {
+			if item.UserData == nil {
+				item.UserData = &service.UserData{}
+			}
+			item.UserData.Played = event.Played
+			if event.Played {
+				item.UserData.PlaybackPositionTicks = 0
+				item.UserData.PlaybackPositionPct = 0
+			}
+		})
+
+	case service.EventRatingChanged:
+		m.applyRating(event.ItemID, event.Likes)
+
+	case service.EventPlaybackStopped:
+		delete(m.sectionCache, SectionResume)
+		m.syncItemState(event.ItemID, func(item *service.MediaItem) {
+			setPlaybackPosition(item, event.PositionSec)
+		})
+	}
+}
+
+type updateAvailableMsg string
+
+func (m *Model) checkUpdate() tea.Cmd {
+	if m.version == "" || m.version == "dev" {
+		return nil
+	}
+	current := m.version
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		release, err := update.Latest(ctx)
+		if err != nil || !update.IsNewer(release.TagName, current) {
+			return nil
+		}
+		return updateAvailableMsg(release.TagName)
+	}
+}
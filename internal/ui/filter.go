@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	filterFieldSort = iota
+	filterFieldOrder
+	filterFieldUnwatched
+	filterFieldGenre
+	filterFieldMinYear
+	filterFieldMaxYear
+	filterFieldCount
+)
+
+var sortLabels = map[string]string{
+	"name":     "Name",
+	"added":    "Date added",
+	"premiere": "Premiere date",
+	"rating":   "Rating",
+}
+
+func (m *Model) openFilter() (tea.Model, tea.Cmd) {
+	if m.view.mode != viewItems {
+		m.status = "Sort/filter is available in library views"
+		return m, nil
+	}
+
+	m.filterDraft = m.itemFilter
+	m.filterCursor = 0
+	m.filterInputs = make([]textinput.Model, 3)
+	values := []string{m.itemFilter.Genre, yearText(m.itemFilter.MinYear), yearText(m.itemFilter.MaxYear)}
+	placeholders := []string{"any genre", "any", "any"}
+	for i := range m.filterInputs {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholders[i]
+		input.SetValue(values[i])
+		input.CharLimit = 40
+		input.Width = 20
+		m.filterInputs[i] = input
+	}
+	m.state = StateFilter
+	return m, nil
+}
+
+func (m *Model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "up", "shift+tab":
+		return m, m.focusFilterField((m.filterCursor - 1 + filterFieldCount) % filterFieldCount)
+
+	case "down", "tab":
+		return m, m.focusFilterField((m.filterCursor + 1) % filterFieldCount)
+
+	case "ctrl+r":
+		m.filterDraft = service.ItemFilter{}
+		for i := range m.filterInputs {
+			m.filterInputs[i].SetValue("")
+		}
+		return m, nil
+
+	case "enter":
+		return m.applyFilter()
+	}
+
+	switch m.filterCursor {
+	case filterFieldSort:
+		switch msg.String() {
+		case "left", "h":
+			m.filterDraft.Sort = cycleString(service.ItemSorts, m.filterDraft.Sort, -1)
+		case "right", "l", " ":
+			m.filterDraft.Sort = cycleString(service.ItemSorts, m.filterDraft.Sort, 1)
+		}
+		return m, nil
+
+	case filterFieldOrder:
+		switch msg.String() {
+		case "left", "h", "right", "l", " ":
+			m.filterDraft.Descending = !m.filterDraft.Descending
+		}
+		return m, nil
+
+	case filterFieldUnwatched:
+		switch msg.String() {
+		case "left", "h", "right", "l", " ":
+			m.filterDraft.Unwatched = !m.filterDraft.Unwatched
+		}
+		return m, nil
+	}
+
+	idx := m.filterCursor - filterFieldGenre
+	var cmd tea.Cmd
+	m.filterInputs[idx], cmd = m.filterInputs[idx].Update(msg)
+	return m, cmd
+}
+
+func (m *Model) focusFilterField(field int) tea.Cmd {
+	for i := range m.filterInputs {
+		m.filterInputs[i].Blur()
+	}
+	m.filterCursor = field
+	if field >= filterFieldGenre {
+		return m.filterInputs[field-filterFieldGenre].Focus()
+	}
+	return nil
+}
+
+func (m *Model) applyFilter() (tea.Model, tea.Cmd) {
+	draft := m.filterDraft
+	draft.Genre = strings.TrimSpace(m.filterInputs[0].Value())
+
+	var err error
+	if draft.MinYear, err = parseYear(m.filterInputs[1].Value()); err != nil {
+		m.status = "Invalid start year"
+		return m, nil
+	}
+	if draft.MaxYear, err = parseYear(m.filterInputs[2].Value()); err != nil {
+		m.status = "Invalid end year"
+		return m, nil
+	}
+	if draft.MinYear > 0 && draft.MaxYear > 0 && draft.MinYear > draft.MaxYear {
+		draft.MinYear, draft.MaxYear = draft.MaxYear, draft.MinYear
+	}
+
+	m.itemFilter = draft
+	return m.reloadFiltered()
+}
+
+func (m *Model) reloadFiltered() (tea.Model, tea.Cmd) {
+	m.page = 0
+	m.cursor = 0
+	m.state = StateLoading
+	m.status = ""
+	return m, m.loadItems(m.view.parentID, 0)
+}
+
+func (m *Model) renderFilter() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Sort & Filter")
+	labelStyle := lipgloss.NewStyle().Width(14)
+
+	order := "Ascending"
+	if m.filterDraft.Descending {
+		order = "Descending"
+	}
+	unwatched := "No"
+	if m.filterDraft.Unwatched {
+		unwatched = "Yes"
+	}
+	sort := m.filterDraft.Sort
+	if sort == "" {
+		sort = "name"
+	}
+
+	values := []string{
+		"< " + sortLabels[sort] + " >",
+		"< " + order + " >",
+		"< " + unwatched + " >",
+		m.filterInputs[0].View(),
+		m.filterInputs[1].View(),
+		m.filterInputs[2].View(),
+	}
+	labels := []string{"Sort by", "Order", "Unwatched", "Genre", "Year from", "Year to"}
+
+	lines := make([]string, filterFieldCount)
+	for i := range lines {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+		if i == m.filterCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+		}
+		lines[i] = lipgloss.JoinHorizontal(lipgloss.Left, style.Render(labelStyle.Render(labels[i])), values[i])
+	}
+
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).MarginTop(1).Render(
+		"[↑↓] field  [←→] change  [Enter] apply  [Ctrl+R] reset  [Esc] cancel",
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
+
+func filterSummary(f service.ItemFilter) string {
+	if f.IsZero() {
+		return ""
+	}
+
+	var parts []string
+	if f.Sort != "" && f.Sort != "name" || f.Descending {
+		sort := f.Sort
+		if sort == "" {
+			sort = "name"
+		}
+		label := strings.ToLower(sortLabels[sort])
+		if f.Descending {
+			label += " ↓"
+		}
+		parts = append(parts, "by "+label)
+	}
+	if f.Unwatched {
+		parts = append(parts, "unwatched")
+	}
+	if f.Genre != "" {
+		parts = append(parts, f.Genre)
+	}
+	switch {
+	case f.MinYear > 0 && f.MaxYear > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d", f.MinYear, f.MaxYear))
+	case f.MinYear > 0:
+		parts = append(parts, fmt.Sprintf("%d+", f.MinYear))
+	case f.MaxYear > 0:
+		parts = append(parts, fmt.Sprintf("≤%d", f.MaxYear))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func cycleString(options []string, current string, delta int) string {
+	idx := 0
+	for i, opt := range options {
+		if opt == current {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + delta + len(options)) % len(options)
+	return options[idx]
+}
+
+func parseYear(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil || year < 1800 || year > 2200 {
+		return 0, fmt.Errorf("invalid year: %s", value)
+	}
+	return year, nil
+}
+
+func yearText(year int) string {
+	if year <= 0 {
+		return ""
+	}
+	return strconv.Itoa(year)
+}
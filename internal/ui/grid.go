@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"strings"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	gridThumbWidth  = 16
+	gridThumbHeight = 10
+	gridCellWidth   = gridThumbWidth + 2
+	gridCellHeight  = gridThumbHeight + 2
+)
+
+func (m *Model) gridLayout(width, height int) (int, int) {
+	cols := max(1, width/gridCellWidth)
+	rows := max(1, height/gridCellHeight)
+	return cols, rows
+}
+
+func (m *Model) gridVisibleRange(width, height int) (int, int) {
+	cols, rows := m.gridLayout(width, height)
+	perPage := cols * rows
+	start := (m.cursor / perPage) * perPage
+	end := min(start+perPage, len(m.items))
+	return start, end
+}
+
+func (m *Model) gridFrame() (int, int) {
+	width, height := m.contentSize()
+	reserved := lipgloss.Height(m.renderContentHeader(width)) + 2
+	return width - 2, height - reserved
+}
+
+func (m *Model) gridCovers() []coverRequest {
+	width, height := m.gridFrame()
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	var covers []coverRequest
+	start, end := m.gridVisibleRange(width, height)
+	for i := start; i < end; i++ {
+		covers = append(covers, coverRequest{coverKey(m.items[i], gridThumbWidth, gridThumbHeight), m.items[i]})
+	}
+	return covers
+}
+
+func (m *Model) renderGrid(width, height int) string {
+	gridWidth, gridHeight := m.gridFrame()
+	cols, _ := m.gridLayout(gridWidth, gridHeight)
+	start, end := m.gridVisibleRange(gridWidth, gridHeight)
+
+	var rows []string
+	var row []string
+	for i := start; i < end; i++ {
+		row = append(row, m.renderGridCell(m.items[i], i == m.cursor))
+		if len(row) == cols {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	grid := lipgloss.NewStyle().
+		Width(width).
+		Height(gridHeight).
+		Align(lipgloss.Center, lipgloss.Top).
+		Render(strings.Join(rows, "\n"))
+
+	nav := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("244")).
+		Align(lipgloss.Center).
+		Width(width).
+		Render(m.navLine())
+
+	parts := []string{grid, nav}
+	if header := m.renderContentHeader(width); header != "" {
+		parts = append([]string{header}, parts...)
+	}
+	return lipgloss.NewStyle().Width(width).Height(height).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+func (m *Model) renderGridCell(item service.MediaItem, selected bool) string {
+	var thumb string
+	if img, ok := m.coverCache.Get(coverKey(item, gridThumbWidth, gridThumbHeight)); ok && img != "" {
+		thumb = lipgloss.NewStyle().
+			Width(gridThumbWidth).
+			Height(gridThumbHeight).
+			MaxWidth(gridThumbWidth).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(img)
+	} else {
+		thumb = m.renderPlaceholder(item, gridThumbWidth, gridThumbHeight, selected)
+	}
+
+	titleStyle := lipgloss.NewStyle().Width(gridThumbWidth).Foreground(lipgloss.Color("244"))
+	if selected {
+		titleStyle = titleStyle.Bold(true).Foreground(lipgloss.Color("0")).Background(lipgloss.Color("212"))
+	}
+	title := titleStyle.Render(truncateText(item.Name, gridThumbWidth))
+
+	return lipgloss.NewStyle().
+		Width(gridCellWidth).
+		Height(gridCellHeight).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, thumb, title, watchedBar(item, gridThumbWidth)))
+}
@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	helpKeyWidth    = 7
+	helpColumnWidth = 52
+)
+
+type keyBinding struct {
+	key   string
+	group string
+	help  string
+	short string
+	kiosk bool
+	when  func(m *Model) bool
+}
+
+var keyBindings = []keyBinding{
+	{key: "←→", group: "Navigation", help: "move or change page", short: "move", kiosk: true},
+	{key: "↑↓", group: "Navigation", help: "move by row in grid view", kiosk: true},
+	{key: "enter", group: "Navigation", help: "open item", short: "open", kiosk: true},
+	{key: "esc", group: "Navigation", help: "go back (also backspace)", short: "back", kiosk: true},
+	{key: "1-9", group: "Navigation", help: "switch sections (8 airing soon, 9 live TV)"},
+	{key: "N", group: "Navigation", help: "recently added"},
+	{key: "L", group: "Navigation", help: "group recently added by library", short: "by library", when: inView(viewLatest)},
+	{key: "G", group: "Navigation", help: "channel guide (Live TV)", short: "guide", when: inView(viewChannels)},
+	{key: "4", group: "Navigation", help: "open search (also /)"},
+	{key: ":", group: "Navigation", help: "command mode (:search, :page, :filter, :server...; tab completes)"},
+	{key: "v", group: "Navigation", help: "toggle grid / carousel view", kiosk: true},
+	{key: "t", group: "Navigation", help: "sort and filter library", short: "sort/filter", when: inView(viewItems)},
+	{key: "y", group: "Navigation", help: "browse genres (of the current library, or all libraries elsewhere)", short: "genres", when: inView(viewItems)},
+	{key: "ctrl+f", group: "Navigation", help: "quick filter: fuzzily narrow the loaded items (esc restores)"},
+	{key: "W", group: "Navigation", help: "hide / show watched items in this view"},
+	{key: "i", group: "Navigation", help: "item details and chapters (+/- like or dislike, ←/→ more like this)", short: "details", when: onItem(anyItem)},
+	{key: "O", group: "Navigation", help: "choose which version of an item to play (remembered per item; c compares them)", short: "versions", when: onItem(playableItem)},
+	{key: "A", group: "Navigation", help: "active sessions on this account (stop playback, sign out devices)"},
+	{key: "T", group: "Navigation", help: "transcoding sessions on the server (D switches your playback to direct play)"},
+	{key: "V", group: "Navigation", help: "re-verify downloaded files", short: "verify", when: inView(viewDownloads)},
+
+	{key: "0", group: "Playback", help: "resume last watched item"},
+	{key: "p", group: "Playback", help: "play current item", short: "play", kiosk: true, when: onItem(playableItem)},
+	{key: "R", group: "Playback", help: "replay from beginning", short: "replay", kiosk: true, when: onItem(playableItem)},
+	{key: "c", group: "Playback", help: "continuous play for episode", short: "continuous", kiosk: true, when: onItem(itemType("Episode"))},
+	{key: "z/Z", group: "Playback", help: "shuffle library, series or Favorites (Z: unwatched only)"},
+	{key: "e", group: "Playback", help: "add episode, movie or season to the queue", short: "enqueue", when: onItem(queueableItem)},
+	{key: "E", group: "Playback", help: "open the queue (J/K reorder, d remove, C clear, M export, enter play)"},
+	{key: "D", group: "Playback", help: "download for offline playback (seasons and series: every episode)", short: "download", when: onItem(playableItem)},
+	{key: "D", group: "Playback", short: "download all", when: onItem(itemType("Season", "Series"))},
+	{key: "M", group: "Playback", help: "export the current season or playlist as an .m3u8 file", short: "export m3u8", when: onItem(itemType("Season", "Playlist"))},
+	{key: "K", group: "Playback", help: "kiosk mode: lock to one library, play-only, PIN to leave", kiosk: true},
+	{key: "K", group: "Playback", short: "unlock (PIN)", kiosk: true, when: inKiosk},
+	{key: "space", group: "Playback", help: "pause; ←/→ seek 10s (while mpv is playing)", kiosk: true},
+	{key: "</>", group: "Playback", help: "previous/next track, n music screen (while playing an album)"},
+
+	{key: "f", group: "Actions", help: "toggle favorite", short: "toggle fav", when: onItem(anyItem)},
+	{key: "w", group: "Actions", help: "toggle watched (on a season or series: mark every episode)", short: "toggle watched", when: onItem(anyItem)},
+	{key: "b", group: "Actions", help: "mark every episode of the season or series you are in", short: "mark all", when: inView(viewSeasons, viewEpisodes)},
+	{key: "x", group: "Actions", help: "run a script action on the current item", short: "scripts", when: onItem(anyItem)},
+	{key: "s", group: "Actions", help: "jump to season", short: "season", when: onItem(itemType("Episode"))},
+	{key: "S", group: "Actions", help: "jump to series", short: "series", when: onItem(itemType("Episode", "Season"))},
+	{key: "g", group: "Navigation", help: "g + letter: jump to the next loaded title starting with it; g enter: season picker", kiosk: true},
+	{key: "r", group: "Actions", help: "refresh current view", kiosk: true},
+	{key: "H", group: "Actions", help: "history: switch between server history and local plays"},
+	{key: "m", group: "Actions", help: "manage servers"},
+	{key: "o", group: "Actions", help: "settings"},
+	{key: "d", group: "Actions", help: "debug log categories (a: audit trail)"},
+	{key: "?", group: "Actions", help: "show or hide this help", short: "all keys", kiosk: true},
+	{key: "q", group: "Actions", help: "quit", short: "quit"},
+}
+
+var helpGroups = []string{"Navigation", "Playback", "Actions"}
+
+func inView(modes ...viewMode) func(*Model) bool {
+	return func(m *Model) bool {
+		return slices.Contains(modes, m.view.mode)
+	}
+}
+
+func inKiosk(m *Model) bool {
+	return m.kiosk != nil
+}
+
+func onItem(pred func(service.MediaItem) bool) func(*Model) bool {
+	return func(m *Model) bool {
+		item, ok := m.currentItem()
+		return ok && pred(item)
+	}
+}
+
+func anyItem(service.MediaItem) bool {
+	return true
+}
+
+func playableItem(item service.MediaItem) bool {
+	return item.Playable
+}
+
+func queueableItem(item service.MediaItem) bool {
+	return item.Playable || item.Type == "Season"
+}
+
+func itemType(types ...string) func(service.MediaItem) bool {
+	return func(item service.MediaItem) bool {
+		return slices.Contains(types, item.Type)
+	}
+}
+
+func (b keyBinding) applies(m *Model) bool {
+	if m.kiosk != nil && !b.kiosk {
+		return false
+	}
+	return b.when == nil || b.when(m)
+}
+
+func (m *Model) contextBindings() []keyBinding {
+	var out []keyBinding
+	for _, b := range keyBindings {
+		if b.short != "" && b.applies(m) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (m *Model) bindingKeys(b keyBinding) string {
+	keys := []string{b.key}
+	var aliases []string
+	for alias, target := range m.keymap {
+		if target == b.key {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return strings.Join(append(keys, aliases...), "/")
+}
+
+func (m *Model) helpLines(width int) []string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(m.accent())
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255")).Width(helpKeyWidth)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	descWidth := max(width-helpKeyWidth-2, 8)
+
+	line := func(key, desc string) string {
+		return "  " + keyStyle.Render(truncateText(key, helpKeyWidth-1)) + dimStyle.Render(truncateText(desc, descWidth))
+	}
+
+	var lines []string
+	if context := m.contextBindings(); len(context) > 0 {
+		title := "This view"
+		if item, ok := m.currentItem(); ok {
+			title += ": " + item.Name
+		}
+		lines = append(lines, headerStyle.Render(truncateText(title, width)))
+		for _, b := range context {
+			lines = append(lines, line(m.bindingKeys(b), b.short))
+		}
+		lines = append(lines, "")
+	}
+	for _, group := range helpGroups {
+		lines = append(lines, headerStyle.Render(group))
+		for _, b := range keyBindings {
+			if b.group != group || b.help == "" || (m.kiosk != nil && !b.kiosk) {
+				continue
+			}
+			lines = append(lines, line(m.bindingKeys(b), b.help))
+		}
+		lines = append(lines, "")
+	}
+	return lines[:len(lines)-1]
+}
+
+func (m *Model) renderHelp(width, height int) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(0, 1)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	inner := max(width-4, 20)
+	columns := max(1, min(3, inner/helpColumnWidth))
+	columnWidth := inner / columns
+	rows := max(height-6, 5)
+
+	lines := m.helpLines(columnWidth - 2)
+	perColumn := max((len(lines)+columns-1)/columns, 1)
+
+	var blocks []string
+	for start := 0; start < len(lines); start += perColumn {
+		column := lines[start:min(start+perColumn, len(lines))]
+		if column[0] == "" {
+			column = column[1:]
+		}
+		blocks = append(blocks, lipgloss.NewStyle().Width(columnWidth).Render(strings.Join(column, "\n")))
+	}
+	body := lipgloss.JoinHorizontal(lipgloss.Top, blocks...)
+
+	hint := dimStyle.Render("? or esc close")
+	if all := strings.Split(body, "\n"); len(all) > rows {
+		m.helpOffset = min(m.helpOffset, len(all)-rows)
+		body = strings.Join(all[m.helpOffset:m.helpOffset+rows], "\n")
+		hint = dimStyle.Render("↑↓ scroll  ? or esc close")
+	}
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("117")).Render("Keybindings")
+	box := style.Width(inner + 2).Render(title + "\n\n" + body + "\n\n" + hint)
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func (m *Model) scrollHelp(key string) {
+	switch key {
+	case "up", "k":
+		m.helpOffset = max(m.helpOffset-1, 0)
+	case "down", "j":
+		m.helpOffset++
+	}
+}
@@ -1,32 +1,45 @@
 package ui
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"image"
 	_ "image/jpeg"
 	_ "image/png"
+	"io"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
 	"ember/internal/logging"
+	"ember/internal/service"
 
 	"github.com/charmbracelet/lipgloss"
-	chafa "github.com/ploMP4/chafa-go"
 	_ "golang.org/x/image/webp"
 )
 
-var (
-	imageCache   = make(map[string]string)
-	imageCacheMu sync.RWMutex
-)
+var imageCache = newLRUCache[imageKey, string]("images", imageCacheSize)
+
+// fetchImage decodes url from the disk cache, or downloads it and keeps
+// the raw bytes there once they decode.
+func fetchImage(ctx context.Context, url string) (image.Image, error) {
+	if data, ok := coverFiles.Get(url); ok {
+		if img, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+			return img, nil
+		}
+	}
 
-func fetchImage(url string) (image.Image, error) {
 	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
-		logging.ImageError(url, 0, "", err)
+		if ctx.Err() == nil {
+			logging.ImageError(url, 0, "", err)
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -37,14 +50,27 @@ func fetchImage(url string) (image.Image, error) {
 		return nil, err
 	}
 
-	img, _, err := image.Decode(resp.Body)
+	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		logging.ImageError(url, resp.StatusCode, resp.Header.Get("Content-Type"), err)
+		if ctx.Err() == nil {
+			logging.ImageError(url, resp.StatusCode, resp.Header.Get("Content-Type"), err)
+		}
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if ctx.Err() == nil {
+			logging.ImageError(url, resp.StatusCode, resp.Header.Get("Content-Type"), err)
+		}
+		return nil, err
 	}
-	return img, err
+	coverFiles.Put(url, data)
+	return img, nil
 }
 
-func RenderImage(urls []string, width, height int) string {
+// RenderImage draws the first of urls that loads. A cancelled ctx stops
+// between steps and leaves nothing in the cache.
+func RenderImage(ctx context.Context, urls []string, width, height int) string {
 	if width <= 0 || height <= 0 {
 		return ""
 	}
@@ -58,20 +84,26 @@ func RenderImage(urls []string, width, height int) string {
 	if len(filtered) == 0 {
 		return renderPlaceholder(width, height)
 	}
+	quality := CurrentImageQuality()
+	if quality == ImageQualityOff {
+		return ""
+	}
 
-	cacheKey := fmt.Sprintf("%s|%dx%d", strings.Join(filtered, "\n"), width, height)
-	imageCacheMu.RLock()
-	if cached, ok := imageCache[cacheKey]; ok {
-		imageCacheMu.RUnlock()
+	renderer := currentRenderer()
+	cacheKey := imageKey{url: strings.Join(filtered, "\n"), width: width, height: height}
+	if cached, ok := imageCache.Get(cacheKey); ok {
 		return cached
 	}
-	imageCacheMu.RUnlock()
 
 	for _, url := range filtered {
-		img, err := fetchImage(url)
+		img, err := fetchImage(ctx, url)
+		if ctx.Err() != nil {
+			return ""
+		}
 		if err != nil {
 			continue
 		}
+		rememberAccent(url, img)
 
 		bounds := img.Bounds()
 		imgWidth := bounds.Dx()
@@ -82,24 +114,42 @@ func RenderImage(urls []string, width, height int) string {
 			continue
 		}
 
-		result := renderChafa(img, renderWidth, renderHeight)
+		result := renderer.Render(img, renderWidth, renderHeight)
 		if strings.TrimSpace(result) == "" {
 			continue
 		}
 
-		imageCacheMu.Lock()
-		imageCache[cacheKey] = result
-		imageCacheMu.Unlock()
+		storeImage(cacheKey, result, renderer, quality)
 		return result
 	}
 
 	placeholder := renderPlaceholder(width, height)
-	imageCacheMu.Lock()
-	imageCache[cacheKey] = placeholder
-	imageCacheMu.Unlock()
+	storeImage(cacheKey, placeholder, renderer, quality)
 	return placeholder
 }
 
+// storeImage caches a rendering unless the renderer or quality changed
+// while it was being drawn; the switch already emptied the cache and the
+// stale result must not land back in it.
+func storeImage(key imageKey, result string, renderer ImageRenderer, quality string) {
+	if currentRenderer().Name() != renderer.Name() || CurrentImageQuality() != quality {
+		return
+	}
+	imageCache.Put(key, result)
+}
+
+// coverURLs lists the candidate poster URLs of an item, best first.
+func coverURLs(item service.MediaItem) []string {
+	if len(item.ImageURLs) == 0 && item.ImageURL != "" {
+		return []string{item.ImageURL}
+	}
+	return item.ImageURLs
+}
+
+func coverKey(item service.MediaItem, width, height int) imageKey {
+	return imageKey{url: strings.Join(coverURLs(item), "\n"), width: width, height: height}
+}
+
 func calculateRenderSize(imgWidth, imgHeight, maxWidth, maxHeight int) (int, int) {
 	if imgWidth <= 0 || imgHeight <= 0 {
 		return maxWidth, maxHeight
@@ -115,61 +165,6 @@ func calculateRenderSize(imgWidth, imgHeight, maxWidth, maxHeight int) (int, int
 	return maxWidth, heightByWidth
 }
 
-func renderChafa(img image.Image, width, height int) string {
-	bounds := img.Bounds()
-	imgWidth := bounds.Dx()
-	imgHeight := bounds.Dy()
-
-	pixels := make([]uint8, imgWidth*imgHeight*4)
-	idx := 0
-	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-		for x := bounds.Min.X; x < bounds.Max.X; x++ {
-			r, g, b, a := img.At(x, y).RGBA()
-			pixels[idx] = uint8(r >> 8)
-			pixels[idx+1] = uint8(g >> 8)
-			pixels[idx+2] = uint8(b >> 8)
-			pixels[idx+3] = uint8(a >> 8)
-			idx += 4
-		}
-	}
-
-	ccfg := chafa.CanvasConfigNew()
-	defer chafa.CanvasConfigUnref(ccfg)
-
-	chafa.CanvasConfigSetGeometry(ccfg, int32(width), int32(height))
-	chafa.CanvasConfigSetCellGeometry(ccfg, 8, 8)
-	chafa.CanvasConfigSetCanvasMode(ccfg, chafa.CHAFA_CANVAS_MODE_TRUECOLOR)
-	chafa.CanvasConfigSetColorSpace(ccfg, chafa.CHAFA_COLOR_SPACE_DIN99D)
-	chafa.CanvasConfigSetPreprocessingEnabled(ccfg, true)
-	chafa.CanvasConfigSetWorkFactor(ccfg, 1.0)
-
-	symbolMap := chafa.SymbolMapNew()
-	defer chafa.SymbolMapUnref(symbolMap)
-	chafa.SymbolMapAddByTags(symbolMap, chafa.CHAFA_SYMBOL_TAG_BLOCK|chafa.CHAFA_SYMBOL_TAG_HALF|chafa.CHAFA_SYMBOL_TAG_QUAD)
-	chafa.CanvasConfigSetSymbolMap(ccfg, symbolMap)
-
-	canvas := chafa.CanvasNew(ccfg)
-	defer chafa.CanvasUnRef(canvas)
-
-	chafa.CanvasDrawAllPixels(
-		canvas,
-		chafa.CHAFA_PIXEL_RGBA8_UNASSOCIATED,
-		pixels,
-		int32(imgWidth),
-		int32(imgHeight),
-		int32(imgWidth*4),
-	)
-
-	termDb := chafa.TermDbGetDefault()
-	termInfo := chafa.TermDbGetFallbackInfo(termDb)
-	defer chafa.TermInfoUnref(termInfo)
-
-	gstr := chafa.CanvasPrint(canvas, termInfo)
-	result := strings.TrimSuffix(gstr.String(), "\n")
-
-	return result
-}
-
 func renderPlaceholder(width, height int) string {
 	style := lipgloss.NewStyle().
 		Width(width).
@@ -182,7 +177,5 @@ func renderPlaceholder(width, height int) string {
 }
 
 func ClearImageCache() {
-	imageCacheMu.Lock()
-	imageCache = make(map[string]string)
-	imageCacheMu.Unlock()
+	imageCache.Clear()
 }
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"context"
+	"sync"
+
+	"ember/internal/logging"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const imageWorkers = 4
+
+// imagePool fetches and renders covers on a fixed number of workers.
+// Requests are served in order and deduplicated by key; Retain drops the
+// ones the user scrolled past and cancels their HTTP requests, so fast
+// scrolling never piles up more than imageWorkers jobs.
+type imagePool struct {
+	mu      sync.Mutex
+	ready   *sync.Cond
+	queue   []*imageJob
+	jobs    map[imageKey]*imageJob
+	results chan imageMsg
+}
+
+type imageJob struct {
+	key    imageKey
+	urls   []string
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newImagePool(workers int) *imagePool {
+	p := &imagePool{
+		jobs:    make(map[imageKey]*imageJob),
+		results: make(chan imageMsg, 64),
+	}
+	p.ready = sync.NewCond(&p.mu)
+	for range workers {
+		go p.work()
+	}
+	return p
+}
+
+// Request queues a rendering unless the same one is already queued or
+// running.
+func (p *imagePool) Request(key imageKey, urls []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.jobs[key]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &imageJob{key: key, urls: urls, ctx: ctx, cancel: cancel}
+	p.jobs[key] = job
+	p.queue = append(p.queue, job)
+	p.ready.Signal()
+}
+
+// Retain cancels every queued or running job whose key is not in keep.
+func (p *imagePool) Retain(keep map[imageKey]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cancelled := 0
+	for key, job := range p.jobs {
+		if !keep[key] {
+			job.cancel()
+			delete(p.jobs, key)
+			cancelled++
+		}
+	}
+	if cancelled == 0 {
+		return
+	}
+	queue := p.queue[:0]
+	for _, job := range p.queue {
+		if job.ctx.Err() == nil {
+			queue = append(queue, job)
+		}
+	}
+	clear(p.queue[len(queue):])
+	p.queue = queue
+	logging.Images("Image jobs cancelled", "cancelled", cancelled, "remaining", len(p.jobs))
+}
+
+func (p *imagePool) work() {
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 {
+			p.ready.Wait()
+		}
+		job := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		img := RenderImage(job.ctx, job.urls, job.key.width, job.key.height)
+
+		p.mu.Lock()
+		if p.jobs[job.key] == job {
+			delete(p.jobs, job.key)
+		}
+		p.mu.Unlock()
+		cancelled := job.ctx.Err() != nil
+		job.cancel()
+
+		if cancelled {
+			continue
+		}
+		p.results <- imageMsg{key: job.key, image: img}
+	}
+}
+
+func waitForImage(p *imagePool) tea.Cmd {
+	return func() tea.Msg {
+		return <-p.results
+	}
+}
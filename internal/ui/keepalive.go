@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"time"
+
+	"ember/internal/logging"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const keepAliveInterval = 60 * time.Second
+
+type keepAliveMsg struct {
+	err error
+}
+
+func (m *Model) keepAlive() tea.Cmd {
+	return func() tea.Msg {
+		return keepAliveMsg{err: m.svc.KeepAlive()}
+	}
+}
+
+func (m *Model) applyKeepAlive(msg keepAliveMsg) tea.Cmd {
+	if msg.err != nil {
+		logging.UI("Session keep-alive failed", "error", msg.err)
+	}
+	return tea.Tick(keepAliveInterval, func(time.Time) tea.Msg {
+		return m.keepAlive()()
+	})
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var namedKeys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"backspace": tea.KeyBackspace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"space":     tea.KeySpace,
+	" ":         tea.KeySpace,
+}
+
+func (m *Model) remapKey(msg tea.KeyMsg) tea.KeyMsg {
+	if m.state != StateBrowsing || len(m.keymap) == 0 {
+		return msg
+	}
+	target, ok := m.keymap[msg.String()]
+	if !ok || target == "" {
+		return msg
+	}
+	return keyMsg(target)
+}
+
+func keyMsg(key string) tea.KeyMsg {
+	if keyType, ok := namedKeys[key]; ok {
+		return tea.KeyMsg{Type: keyType}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"errors"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var kioskKeys = map[string]bool{
+	"left": true, "right": true, "up": true, "down": true,
+	"h": true, "j": true, "k": true, "l": true,
+	"enter": true, "esc": true, "backspace": true, " ": true,
+	"p": true, "R": true, "c": true, "g": true, "v": true, "r": true,
+}
+
+type kioskLibrariesMsg struct {
+	libraries []service.MediaItem
+	err       error
+}
+
+func (m *Model) openKioskPicker() tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetLibraries()
+		if err != nil {
+			return kioskLibrariesMsg{err: err}
+		}
+		return kioskLibrariesMsg{libraries: list.Items}
+	}
+}
+
+func (m *Model) applyKioskLibraries(msg kioskLibrariesMsg) {
+	if msg.err != nil {
+		m.status = "Cannot list libraries: " + msg.err.Error()
+		return
+	}
+	if len(msg.libraries) == 0 {
+		m.status = "No libraries"
+		return
+	}
+
+	m.kioskLibs = msg.libraries
+	m.kioskCursor = 0
+	for i, lib := range msg.libraries {
+		if m.currentLib != nil && lib.ID == m.currentLib.ID {
+			m.kioskCursor = i
+		}
+	}
+	m.state = StateKioskPicker
+}
+
+func (m *Model) handleKioskPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "K":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "up", "k":
+		if m.kioskCursor > 0 {
+			m.kioskCursor--
+		}
+
+	case "down", "j":
+		if m.kioskCursor < len(m.kioskLibs)-1 {
+			m.kioskCursor++
+		}
+
+	case "enter":
+		lib := m.kioskLibs[m.kioskCursor]
+		if !m.svc.HasKioskPIN() {
+			return m, m.promptKioskPIN(&lib)
+		}
+		return m.enterKiosk(lib)
+	}
+
+	return m, nil
+}
+
+func (m *Model) promptKioskPIN(target *service.MediaItem) tea.Cmd {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Placeholder = "PIN"
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	input.CharLimit = 32
+	input.Width = 20
+
+	m.kioskInput = input
+	m.kioskTarget = target
+	m.state = StateKioskPIN
+	return m.kioskInput.Focus()
+}
+
+func (m *Model) handleKioskPINKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.kioskInput.Blur()
+		m.kioskTarget = nil
+		m.state = StateBrowsing
+		return m, nil
+
+	case "enter":
+		pin := m.kioskInput.Value()
+		m.kioskInput.Reset()
+
+		if m.kioskTarget != nil {
+			if err := m.svc.SetKioskPIN(pin); err != nil {
+				m.status = "Kiosk PIN: " + err.Error()
+				return m, nil
+			}
+			lib := *m.kioskTarget
+			m.kioskTarget = nil
+			return m.enterKiosk(lib)
+		}
+
+		if err := m.svc.ExitKiosk(pin); err != nil {
+			if errors.Is(err, service.ErrKioskPIN) {
+				m.status = "Wrong PIN"
+			} else {
+				m.status = "Cannot unlock: " + err.Error()
+			}
+			return m, nil
+		}
+		m.kiosk = nil
+		m.kioskInput.Blur()
+		m.currentLib = nil
+		m.sectionCache = make(map[Section][]service.MediaItem)
+		model, cmd := m.switchSection(SectionResume, m.loadResume)
+		m.status = "Kiosk mode off"
+		return model, cmd
+	}
+
+	var cmd tea.Cmd
+	m.kioskInput, cmd = m.kioskInput.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) enterKiosk(lib service.MediaItem) (tea.Model, tea.Cmd) {
+	lock := service.KioskLock{LibraryID: lib.ID, LibraryName: lib.Name}
+	if err := m.svc.EnterKiosk(lock); err != nil {
+		m.status = "Cannot start kiosk mode: " + err.Error()
+		m.state = StateBrowsing
+		return m, nil
+	}
+
+	m.kiosk = &lock
+	m.kioskInput.Blur()
+	m.sectionCursor[m.section] = m.cursor
+	m.showKioskLibrary()
+	m.status = "Kiosk mode: " + lib.Name
+	return m, m.loadActiveView()
+}
+
+func (m *Model) showKioskLibrary() {
+	m.navStack = nil
+	m.currentLib = &service.MediaItem{ID: m.kiosk.LibraryID, Name: m.kiosk.LibraryName, Type: "CollectionFolder"}
+	m.itemFilter = service.ItemFilter{}
+	m.view = viewState{mode: viewItems, parentID: m.kiosk.LibraryID}
+	m.page = 0
+	m.cursor = 0
+	m.keepCursor = false
+	m.state = StateLoading
+}
+
+func (m *Model) handleKioskKey(key string) (tea.Cmd, bool) {
+	if m.kiosk == nil {
+		return nil, false
+	}
+	switch key {
+	case "K", "q", "ctrl+c":
+		return m.promptKioskPIN(nil), true
+	}
+	return nil, !kioskKeys[key]
+}
+
+func (m *Model) renderKioskPicker() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Kiosk mode: choose a library")
+
+	lines := make([]string, len(m.kioskLibs))
+	for i, lib := range m.kioskLibs {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+		prefix := "  "
+		if i == m.kioskCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+			prefix = "> "
+		}
+		lines[i] = style.Render(prefix + truncateText(lib.Name, 30))
+	}
+
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).MarginTop(1).Render(
+		"[↑↓] select  [enter] lock to library  [esc] back",
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
+
+func (m *Model) renderKioskPIN() string {
+	label := "Enter PIN to leave kiosk mode"
+	if m.kioskTarget != nil {
+		label = "Choose a PIN for leaving kiosk mode"
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render(label)
+
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).MarginTop(1).Render(
+		"[enter] confirm  [esc] cancel",
+	)
+
+	parts := []string{title, m.kioskInput.View()}
+	if m.status == "Wrong PIN" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(m.status))
+	}
+	parts = append(parts, hint)
+	return lipgloss.JoinVertical(lipgloss.Center, parts...)
+}
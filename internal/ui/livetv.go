@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	guideSlot         = 30 * time.Minute
+	guideSlotWidth    = 20
+	guideChannelWidth = 18
+)
+
+type guideMsg struct {
+	rows  []service.GuideRow
+	start time.Time
+	err   error
+}
+
+func (m *Model) loadChannels(page int) tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.svc.GetChannels(page, m.pageSize)
+		if err != nil {
+			return itemsMsg{err: err}
+		}
+		return itemsMsg{items: list.Items, total: list.Total}
+	}
+}
+
+func (m *Model) guideSlots() int {
+	contentWidth, _ := m.contentSize()
+	return max(1, min(8, (contentWidth-guideChannelWidth-8)/guideSlotWidth))
+}
+
+func (m *Model) loadGuide(start time.Time) tea.Cmd {
+	channels := m.items
+	span := time.Duration(m.guideSlots()) * guideSlot
+	return func() tea.Msg {
+		rows, err := m.svc.GetGuide(channels, start, span)
+		return guideMsg{rows: rows, start: start, err: err}
+	}
+}
+
+func (m *Model) openGuide() (tea.Model, tea.Cmd) {
+	if m.view.mode != viewChannels || len(m.items) == 0 {
+		m.status = "The guide is available in Live TV (9)"
+		return m, nil
+	}
+	m.guideCursor = m.cursor
+	m.status = "Loading guide..."
+	return m, m.loadGuide(time.Now().Truncate(guideSlot))
+}
+
+func (m *Model) applyGuide(msg guideMsg) {
+	if msg.err != nil {
+		m.status = "Cannot load guide: " + msg.err.Error()
+		return
+	}
+	m.guideRows = msg.rows
+	m.guideStart = msg.start
+	m.guideCursor = min(m.guideCursor, max(len(msg.rows)-1, 0))
+	m.status = ""
+	m.state = StateGuide
+}
+
+func (m *Model) handleGuideKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "G":
+		m.cursor = m.guideCursor
+		m.state = StateBrowsing
+		return m, m.loadVisibleImages()
+
+	case "up", "k":
+		if m.guideCursor > 0 {
+			m.guideCursor--
+		}
+
+	case "down", "j":
+		if m.guideCursor < len(m.guideRows)-1 {
+			m.guideCursor++
+		}
+
+	case "left", "h":
+		if now := time.Now().Truncate(guideSlot); m.guideStart.After(now) {
+			return m, m.loadGuide(m.guideStart.Add(-guideSlot))
+		}
+
+	case "right", "l":
+		return m, m.loadGuide(m.guideStart.Add(guideSlot))
+
+	case "enter", "p":
+		if m.guideCursor >= len(m.guideRows) {
+			return m, nil
+		}
+		m.cursor = m.guideCursor
+		m.state = StateBrowsing
+		return m.playItem(m.guideRows[m.guideCursor].Channel, false)
+	}
+
+	return m, nil
+}
+
+func (m *Model) renderGuide(width, height int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	airingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("117"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+
+	slots := m.guideSlots()
+	end := m.guideStart.Add(time.Duration(slots) * guideSlot)
+	header := strings.Repeat(" ", guideChannelWidth)
+	for i := 0; i < slots; i++ {
+		label := m.guideStart.Add(time.Duration(i) * guideSlot).Format("15:04")
+		header += fmt.Sprintf("%-*s", guideSlotWidth, label)
+	}
+
+	lines := []string{
+		titleStyle.Render("Channel Guide  " + m.guideStart.Format("Mon Jan 2")),
+		"",
+		dimStyle.Render(header),
+	}
+
+	now := time.Now()
+	start, stop := visibleRange(m.guideCursor, len(m.guideRows), max(3, height-8))
+	for i := start; i < stop; i++ {
+		row := m.guideRows[i]
+		name := row.Channel.Name
+		if row.Channel.Channel != "" {
+			name = row.Channel.Channel + " " + name
+		}
+		channelStyle := dimStyle
+		prefix := "  "
+		if i == m.guideCursor {
+			channelStyle = selectedStyle
+			prefix = "> "
+		}
+		line := channelStyle.Render(fmt.Sprintf("%-*s", guideChannelWidth, prefix+truncateText(name, guideChannelWidth-3)))
+
+		col := 0
+		for _, p := range row.Programs {
+			if !p.End.After(m.guideStart) || !p.Start.Before(end) {
+				continue
+			}
+			from := guideColumn(m.guideStart, p.Start, slots)
+			to := guideColumn(m.guideStart, p.End, slots)
+			if from < col {
+				from = col
+			}
+			if to-from < 2 {
+				continue
+			}
+			if from > col {
+				line += strings.Repeat(" ", from-col)
+			}
+			cell := fmt.Sprintf("│%-*s", to-from-1, truncateText(p.Name, to-from-1))
+			style := dimStyle
+			if !now.Before(p.Start) && now.Before(p.End) {
+				style = airingStyle
+			}
+			if i == m.guideCursor {
+				style = style.Bold(true)
+			}
+			line += style.Render(cell)
+			col = to
+		}
+		if len(row.Programs) == 0 {
+			line += dimStyle.Render("│No guide data")
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", dimStyle.Render("[↑↓] channel  [←→] time  [enter] watch  [esc] back"))
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+func guideColumn(start, t time.Time, slots int) int {
+	col := int(t.Sub(start) * guideSlotWidth / guideSlot)
+	return max(0, min(col, slots*guideSlotWidth))
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"ember/internal/logging"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) handleLogPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "d":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "a":
+		return m.openAudit()
+
+	case "up", "k":
+		if m.logCursor > 0 {
+			m.logCursor--
+		}
+
+	case "down", "j":
+		if m.logCursor < len(logging.Categories) {
+			m.logCursor++
+		}
+
+	case " ", "enter", "left", "right", "h", "l":
+		if m.logCursor == 0 {
+			m.loggingEnabled = !m.loggingEnabled
+			logging.SetEnabled(m.loggingEnabled)
+			m.status = "Debug logging: " + onOff(m.loggingEnabled)
+			return m, nil
+		}
+
+		category := logging.Categories[m.logCursor-1]
+		enabled := !logging.CategoryEnabled(category)
+		m.svc.SetLogCategoryEnabled(category, enabled)
+		m.status = "Logging " + string(category) + ": " + onOff(enabled)
+	}
+
+	return m, nil
+}
+
+func (m *Model) renderLogPicker() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Debug Logging")
+	labelStyle := lipgloss.NewStyle().Width(18)
+
+	labels := []string{"All logging"}
+	values := []bool{m.loggingEnabled}
+	for _, c := range logging.Categories {
+		labels = append(labels, "  "+string(c))
+		values = append(values, logging.CategoryEnabled(c))
+	}
+
+	lines := make([]string, len(labels))
+	for i, label := range labels {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+		if i > 0 && !m.loggingEnabled {
+			style = style.Faint(true)
+		}
+		if i == m.logCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+		}
+		check := "[ ]"
+		if values[i] {
+			check = "[x]"
+		}
+		lines[i] = style.Render(labelStyle.Render(label) + check)
+	}
+
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).MarginTop(1).Render(
+		"[↑↓] select  [space] toggle  [a] audit trail  [esc] back",
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
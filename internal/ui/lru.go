@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"container/list"
+	"sync"
+
+	"ember/internal/logging"
+)
+
+const (
+	coverCacheSize  = 256
+	imageCacheSize  = 512
+	accentCacheSize = 1024
+
+	// lruLogEvery is how many evictions pass between two stats lines in
+	// the debug log, so scrolling a large library doesn't flood it.
+	lruLogEvery = 50
+)
+
+// imageKey identifies one rendering of a picture. url is the newline-joined
+// list of candidate URLs (or another stable name for the picture) and the
+// size is in terminal cells.
+type imageKey struct {
+	url    string
+	width  int
+	height int
+}
+
+// lruCache is a size-bounded, goroutine-safe least-recently-used cache.
+type lruCache[K comparable, V any] struct {
+	name     string
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[K]*list.Element
+
+	hits      int
+	misses    int
+	evictions int
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRUCache[K comparable, V any](name string, capacity int) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		name:     name,
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  make(map[K]*list.Element),
+	}
+}
+
+func (c *lruCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+func (c *lruCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+		c.evictions++
+		if c.evictions%lruLogEvery == 0 {
+			c.logStats("Cache evictions")
+		}
+	}
+}
+
+func (c *lruCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logStats("Cache cleared")
+	c.order.Init()
+	c.entries = make(map[K]*list.Element)
+}
+
+// logStats must be called with c.mu held.
+func (c *lruCache[K, V]) logStats(msg string) {
+	logging.Images(msg,
+		"cache", c.name,
+		"size", c.order.Len(),
+		"capacity", c.capacity,
+		"hits", c.hits,
+		"misses", c.misses,
+		"evictions", c.evictions,
+	)
+}
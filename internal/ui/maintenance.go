@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"time"
+
+	"ember/internal/logging"
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) applyPing(msg pingMsg) tea.Cmd {
+	prev := m.maintenance
+	m.maintenance = msg.maintenance
+	if prev != msg.maintenance {
+		logging.UI("Server maintenance state changed", "from", prev, "to", msg.maintenance)
+	}
+
+	interval := 10 * time.Second
+	if msg.maintenance == service.MaintenanceRestarting {
+		interval = 3 * time.Second
+	}
+	cmds := []tea.Cmd{
+		m.syncPendingReports(),
+		tea.Tick(interval, func(time.Time) tea.Msg {
+			return m.pingServer()()
+		}),
+	}
+
+	if prev == service.MaintenanceRestarting && msg.maintenance != service.MaintenanceRestarting {
+		m.status = "Server is back"
+		if m.state == StateBrowsing || m.state == StateLoading {
+			_, cmd := m.refreshCurrentView()
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) maintenanceBanner() string {
+	switch m.maintenance {
+	case service.MaintenanceRestarting:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true).Render("Server restarting, retrying...")
+	case service.MaintenanceRestartPending:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("Server restart pending")
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) renderMini() string {
+	width := max(20, m.width)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+
+	header := titleStyle.Render("EMBER") + dimStyle.Render(" · "+m.sectionName())
+	if len(m.items) > 0 {
+		header += dimStyle.Render(fmt.Sprintf(" (%d/%d)", m.cursor+1, m.totalItems))
+	}
+	lines := []string{header}
+
+	switch {
+	case m.state == StateSearching:
+		lines = append(lines, "Search: "+m.searchInput.View())
+	case m.state == StateLoading:
+		lines = append(lines, m.spinner.View()+" Loading...")
+	case len(m.items) == 0:
+		lines = append(lines, dimStyle.Render(m.emptyStateText()))
+	default:
+		listHeight := max(1, m.height-3)
+		start, end := visibleRange(m.cursor, len(m.items), listHeight)
+		for i := start; i < end; i++ {
+			item := m.items[i]
+			label := miniLabel(item.Name, item.IndexNumber, itemContext(item))
+			meta := strings.Join(itemMeta(item)[1:], " ")
+			line := truncateText(label, width-len([]rune(meta))-4)
+			if meta != "" {
+				line += "  " + dimStyle.Render(meta)
+			}
+			if i == m.cursor {
+				lines = append(lines, selectedStyle.Render("> ")+selectedStyle.Render(line))
+			} else {
+				lines = append(lines, "  "+line)
+			}
+		}
+	}
+
+	footer := "enter play/open  0 resume  1-7 section  / search  q quit"
+	if strings.TrimSpace(m.status) != "" {
+		footer = m.status
+	}
+	if toast, ok := m.latestToast(); ok {
+		footer = toast
+	}
+	lines = append(lines, dimStyle.Render(truncateText(footer, width)))
+
+	return strings.Join(lines, "\n")
+}
+
+func miniLabel(name string, index int, context string) string {
+	label := name
+	if index > 0 {
+		label = fmt.Sprintf("E%02d %s", index, name)
+	}
+	if context != "" {
+		label = context + " · " + label
+	}
+	return label
+}
+
+func (m *Model) sectionName() string {
+	switch m.activeSection() {
+	case SectionResume:
+		return "Continue"
+	case SectionFavorites:
+		return "Favorites"
+	case SectionHistory:
+		return "History"
+	case SectionSearch:
+		return "Search"
+	case SectionReleased:
+		return "New Releases"
+	case SectionStudios:
+		return "Studios"
+	case SectionDownloads:
+		return "Downloads"
+	case SectionAiring:
+		return "Airing Soon"
+	case SectionLiveTV:
+		return "Live TV"
+	case SectionLatest:
+		return "Recently Added"
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	homePageSize    = 50
+	loadMoreReserve = 10
+)
+
+type moreItemsMsg struct {
+	section Section
+	after   string
+	items   []service.MediaItem
+	hidden  int
+	next    string
+	err     error
+}
+
+func (m *Model) loadMore() tea.Cmd {
+	if m.moreAfter == "" || m.loadingMore || m.quickBase != nil || len(m.navStack) > 0 || m.cursor < len(m.items)-loadMoreReserve {
+		return nil
+	}
+	section, after := m.section, m.moreAfter
+	var fetch func(string, int) (*service.MediaList, error)
+	switch {
+	case section == SectionResume && m.view.mode == viewResume:
+		fetch = m.svc.GetResumeAfter
+	case section == SectionFavorites && m.view.mode == viewFavorites:
+		fetch = m.svc.GetFavoritesAfter
+	default:
+		return nil
+	}
+	m.loadingMore = true
+	return func() tea.Msg {
+		list, err := fetch(after, homePageSize)
+		if err != nil {
+			return moreItemsMsg{section: section, after: after, err: err}
+		}
+		return moreItemsMsg{section: section, after: after, items: list.Items, hidden: list.Hidden, next: list.Next}
+	}
+}
+
+func (m *Model) applyMoreItems(msg moreItemsMsg) tea.Cmd {
+	m.loadingMore = false
+	if msg.section != m.section || msg.after != m.moreAfter {
+		return nil
+	}
+	if msg.err != nil {
+		m.status = "Failed to load more: " + msg.err.Error()
+		return nil
+	}
+	seen := make(map[string]bool, len(m.items))
+	for _, item := range m.items {
+		seen[item.ID] = true
+	}
+	for _, item := range msg.items {
+		if !seen[item.ID] {
+			m.items = append(m.items, item)
+		}
+	}
+	m.totalItems = len(m.items)
+	m.hiddenItems += msg.hidden
+	m.moreAfter = msg.next
+	m.sectionCache[m.section] = m.items
+	m.sectionMore[m.section] = msg.next
+	return m.loadVisibleImages()
+}
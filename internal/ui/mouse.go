@@ -0,0 +1,133 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleMouse turns clicks and wheel turns into the key presses they stand
+// for, so kiosk restrictions and every view's own key handling still apply.
+func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	m.noteInput()
+
+	wheel := ""
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		wheel = "up"
+	case tea.MouseButtonWheelDown:
+		wheel = "down"
+	case tea.MouseButtonLeft:
+	default:
+		return m, nil
+	}
+
+	if m.helpVisible {
+		m.scrollHelp(wheel)
+		return m, nil
+	}
+	if m.command.Focused() {
+		return m, nil
+	}
+
+	if m.state != StateBrowsing || m.mini {
+		if m.state == StateServerManage && wheel == "" {
+			return m.clickServer(msg.Y)
+		}
+		if wheel != "" {
+			return m.handleKey(keyMsg(wheel))
+		}
+		return m, nil
+	}
+
+	if wheel != "" {
+		if !m.gridMode {
+			wheel = map[string]string{"up": "left", "down": "right"}[wheel]
+		}
+		return m.handleKey(keyMsg(wheel))
+	}
+
+	if msg.X < m.statusWidth() {
+		return m.clickSidebar(msg.Y)
+	}
+	width, height := m.contentSize()
+	if m.gridMode {
+		return m.clickGrid(msg.X-m.statusWidth(), msg.Y, width)
+	}
+	return m.clickCarousel(msg.X-m.statusWidth(), msg.Y, width, height)
+}
+
+func (m *Model) clickSidebar(y int) (tea.Model, tea.Cmd) {
+	if m.kiosk != nil {
+		return m, nil
+	}
+	row := y - m.sidebarNavTop()
+	if row < 0 || row >= len(sidebarSections) {
+		return m, nil
+	}
+	return m.handleKey(keyMsg(sidebarSections[row].key))
+}
+
+func (m *Model) contentHeaderHeight(width int) int {
+	if header := m.renderContentHeader(width); header != "" {
+		return lipgloss.Height(header)
+	}
+	return 0
+}
+
+// clickCarousel opens the item when its cover is clicked and steps back or
+// forward when the left or right half of the navigation line is clicked.
+func (m *Model) clickCarousel(x, y, width, height int) (tea.Model, tea.Cmd) {
+	top := m.contentHeaderHeight(width)
+	_, coverHeight := m.coverFrame(width, height)
+	switch row := y - top; {
+	case row >= 0 && row < coverHeight:
+		return m.handleKey(keyMsg("enter"))
+	case row == coverHeight+3 && x < width/2:
+		return m.handleKey(keyMsg("left"))
+	case row == coverHeight+3:
+		return m.handleKey(keyMsg("right"))
+	}
+	return m, nil
+}
+
+// clickGrid selects the clicked poster, or opens it when it is already
+// selected.
+func (m *Model) clickGrid(x, y, width int) (tea.Model, tea.Cmd) {
+	gridWidth, gridHeight := m.gridFrame()
+	cols, _ := m.gridLayout(gridWidth, gridHeight)
+	start, end := m.gridVisibleRange(gridWidth, gridHeight)
+
+	x -= (width - cols*gridCellWidth) / 2
+	y -= m.contentHeaderHeight(width)
+	if x < 0 || y < 0 || x >= cols*gridCellWidth {
+		return m, nil
+	}
+	index := start + (y/gridCellHeight)*cols + x/gridCellWidth
+	if index >= end {
+		return m, nil
+	}
+	if index == m.cursor {
+		return m.handleKey(keyMsg("enter"))
+	}
+	return m.moveCursor(index - m.cursor)
+}
+
+// clickServer selects the clicked server row, or connects to it when it is
+// already selected. The server list is centered in the content area below
+// its title and the title's margin.
+func (m *Model) clickServer(y int) (tea.Model, tea.Cmd) {
+	_, height := m.contentSize()
+	top := (height - lipgloss.Height(m.renderServerManage())) / 2
+	row := y - top - 2
+	if row < 0 || row >= len(m.svc.GetServers()) {
+		return m, nil
+	}
+	if row == m.serverCursor {
+		return m.handleKey(keyMsg("enter"))
+	}
+	m.serverCursor = row
+	return m, nil
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"ember/internal/player"
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	musicArtWidth  = 36
+	musicArtHeight = 18
+)
+
+type audioPlanMsg struct {
+	plan *service.ContinuousPlaybackPlan
+	err  error
+}
+
+func (m *Model) playAlbum(item service.MediaItem) (tea.Model, tea.Cmd) {
+	m.status = "Loading tracks..."
+	m.nowPlaying = service.MediaTitle(item)
+	return m, tea.Batch(tickPlayback(), func() tea.Msg {
+		plan, err := m.svc.BuildAlbumPlayback(item)
+		return audioPlanMsg{plan: plan, err: err}
+	})
+}
+
+func (m *Model) applyAudioPlan(msg audioPlanMsg) tea.Cmd {
+	if msg.err != nil {
+		m.nowPlaying = ""
+		m.status = "Cannot play: " + msg.err.Error()
+		return nil
+	}
+	plan := msg.plan
+	m.audioPlan = plan
+	m.musicView = true
+	m.status = fmt.Sprintf("Playing %s (%d tracks)", plan.Title, len(plan.Items))
+	m.loadTrackArt()
+	return func() tea.Msg {
+		return m.playPlan(plan, nil)
+	}
+}
+
+func (m *Model) currentTrack() (int, service.MediaItem, bool) {
+	if m.audioPlan == nil || len(m.audioPlan.Items) == 0 {
+		return 0, service.MediaItem{}, false
+	}
+	index := m.audioPlan.StartIndex
+	if ctrl := player.Current(); ctrl != nil {
+		index = ctrl.Index()
+	}
+	if index < 0 || index >= len(m.audioPlan.Items) {
+		return 0, service.MediaItem{}, false
+	}
+	return index, m.audioPlan.Items[index], true
+}
+
+// trackArtKey keys album art by album, so every track of an album shares
+// one rendering instead of fetching its own copy.
+func trackArtKey(item service.MediaItem) imageKey {
+	id := item.ID
+	if item.AlbumID != "" {
+		id = item.AlbumID
+	}
+	return imageKey{url: "art:" + id, width: musicArtWidth, height: musicArtHeight}
+}
+
+func (m *Model) loadTrackArt() {
+	if _, track, ok := m.currentTrack(); ok && !m.mini {
+		m.requestImage(trackArtKey(track), track)
+	}
+}
+
+func (m *Model) renderMusic(width, height int) string {
+	index, track, ok := m.currentTrack()
+	if !ok {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("117"))
+
+	art, _ := m.coverCache.Get(trackArtKey(track))
+	if art == "" {
+		art = m.renderEmptyCover(musicArtWidth, musicArtHeight)
+	}
+
+	infoWidth := max(width-musicArtWidth-4, 20)
+	var pos, dur int64
+	paused := false
+	if ctrl := player.Current(); ctrl != nil {
+		pos, dur, paused = ctrl.Position(), ctrl.Duration(), ctrl.Paused()
+	}
+	if dur == 0 {
+		dur = track.RunTimeTicks / 10_000_000
+	}
+	barWidth := max(infoWidth-2, 4)
+	filled := 0
+	if dur > 0 {
+		filled = min(barWidth, int(pos*int64(barWidth)/dur))
+	}
+	bar := lipgloss.NewStyle().Foreground(itemAccent(track)).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render(strings.Repeat("─", barWidth-filled))
+	clock := formatDuration(pos) + " / " + formatDuration(dur)
+	if paused {
+		clock += lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("  paused")
+	}
+
+	info := []string{
+		titleStyle.Render(truncateText(track.Name, infoWidth)),
+		dimStyle.Render(truncateText(track.Artist, infoWidth)),
+		dimStyle.Render(truncateText(track.Album, infoWidth)),
+		"",
+		bar,
+		dimStyle.Render(clock),
+		"",
+		headerStyle.Render(fmt.Sprintf("Queue  %d / %d", index+1, len(m.audioPlan.Items))),
+	}
+	start, end := visibleRange(index, len(m.audioPlan.Items), max(3, musicArtHeight-len(info)))
+	for i := start; i < end; i++ {
+		item := m.audioPlan.Items[i]
+		style := dimStyle
+		prefix := "  "
+		if i == index {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+			prefix = "> "
+		}
+		line := fmt.Sprintf("%2d. %s", i+1, item.Name)
+		if item.RunTimeTicks > 0 {
+			line += "  " + formatDuration(item.RunTimeTicks/10_000_000)
+		}
+		info = append(info, style.Render(prefix+truncateText(line, infoWidth-2)))
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(musicArtWidth).Render(art),
+		"    ",
+		strings.Join(info, "\n"),
+	)
+	hint := dimStyle.Render("[space] pause  [←/→] seek  [</>] previous/next  [n] back to library")
+	return lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(body + "\n\n" + hint)
+}
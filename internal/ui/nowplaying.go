@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ember/internal/player"
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const seekStepSec = 10
+
+type playbackTickMsg struct{}
+
+type playbackMarkersMsg struct {
+	itemID  string
+	markers []service.PlaybackMarker
+}
+
+func tickPlayback() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return playbackTickMsg{}
+	})
+}
+
+func (m *Model) handlePlaybackKey(key string) (tea.Cmd, bool) {
+	if m.nowPlaying == "" {
+		return nil, false
+	}
+	ctrl := player.Current()
+	if ctrl == nil {
+		return nil, false
+	}
+
+	var err error
+	switch key {
+	case "left":
+		err = ctrl.Seek(-seekStepSec)
+	case "right":
+		err = ctrl.Seek(seekStepSec)
+	case " ":
+		err = ctrl.TogglePause()
+	case ">":
+		err = ctrl.Next()
+	case "<":
+		err = ctrl.Previous()
+	case "n":
+		if m.audioPlan == nil {
+			return nil, false
+		}
+		m.musicView = !m.musicView
+	default:
+		return nil, false
+	}
+	if err != nil {
+		m.status = "Player control failed: " + err.Error()
+	}
+	return nil, true
+}
+
+func (m *Model) renderNowPlaying(width int) []string {
+	if m.nowPlaying == "" {
+		return nil
+	}
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	highlightStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("117"))
+
+	lines := []string{highlightStyle.Render("Now Playing:"), dimStyle.Render(truncateText(m.nowPlaying, width-4))}
+	ctrl := player.Current()
+	if ctrl == nil {
+		return append(lines, dimStyle.Render("Starting mpv..."))
+	}
+
+	pos, dur := ctrl.Position(), ctrl.Duration()
+	bar := m.renderProgressBar(pos, dur, max(width-6, 4))
+
+	clock := formatDuration(pos)
+	if dur > 0 {
+		clock += " / " + formatDuration(dur)
+	}
+	if ctrl.Paused() {
+		clock += lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("  paused")
+	}
+	hint := "←/→ seek  space pause"
+	if m.audioPlan != nil {
+		hint = "←/→ seek  </> track  n music"
+	}
+	lines = append(lines, bar, dimStyle.Render(clock))
+	if marker := m.markerCaption(pos); marker != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(truncateText(marker, width-4)))
+	}
+	return append(lines, dimStyle.Render(hint))
+}
+
+func (m *Model) loadPlaybackMarkers(item service.MediaItem) tea.Cmd {
+	if item.Type == "TvChannel" {
+		return nil
+	}
+	return func() tea.Msg {
+		return playbackMarkersMsg{itemID: item.ID, markers: m.svc.PlaybackMarkers(item)}
+	}
+}
+
+// renderProgressBar draws the playback bar with intro and credit segments
+// in orange and a tick at every chapter boundary.
+func (m *Model) renderProgressBar(pos, dur int64, width int) string {
+	cell := func(sec int64) int {
+		return min(width-1, int(sec*int64(width)/dur))
+	}
+	filled := 0
+	if dur > 0 {
+		filled = min(width, int(pos*int64(width)/dur))
+	}
+
+	chars := make([]string, width)
+	colors := make([]lipgloss.TerminalColor, width)
+	for i := range chars {
+		chars[i], colors[i] = "─", lipgloss.Color("238")
+		if i < filled {
+			chars[i], colors[i] = "━", m.accent()
+		}
+	}
+	if dur > 0 {
+		for _, mk := range m.playMarkers {
+			if mk.StartSec >= dur {
+				continue
+			}
+			if mk.EndSec == 0 {
+				i := cell(mk.StartSec)
+				chars[i], colors[i] = "┼", lipgloss.Color("250")
+				if i < filled {
+					chars[i] = "╋"
+				}
+				continue
+			}
+			for i := cell(mk.StartSec); i <= cell(min(mk.EndSec, dur)-1); i++ {
+				colors[i] = lipgloss.Color("136")
+				if i < filled {
+					colors[i] = lipgloss.Color("214")
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	for start := 0; start < width; {
+		end := start + 1
+		for end < width && colors[end] == colors[start] {
+			end++
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(colors[start]).Render(strings.Join(chars[start:end], "")))
+		start = end
+	}
+	return b.String()
+}
+
+func (m *Model) markerCaption(pos int64) string {
+	chapter := ""
+	for _, mk := range m.playMarkers {
+		if mk.EndSec > 0 && pos >= mk.StartSec && pos < mk.EndSec {
+			return fmt.Sprintf("%s until %s", mk.Kind, formatDuration(mk.EndSec))
+		}
+		if mk.EndSec > 0 && mk.Kind == service.MarkerIntro && pos < mk.StartSec {
+			return fmt.Sprintf("intro at %s-%s", formatDuration(mk.StartSec), formatDuration(mk.EndSec))
+		}
+		if mk.Kind == service.MarkerChapter && mk.StartSec <= pos {
+			chapter = mk.Name
+		}
+	}
+	return chapter
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"context"
+	"sync"
+
+	"ember/internal/logging"
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const prerenderWorkers = 2
+
+type prerenderDoneMsg struct {
+	generation int64
+	rendered   int
+}
+
+func (m *Model) prerenderCovers() tea.Cmd {
+	if m.mini || len(m.items) == 0 || m.width <= 0 || m.height <= 0 || CurrentImageQuality() == ImageQualityOff {
+		return nil
+	}
+
+	width, height := gridThumbWidth, gridThumbHeight
+	if !m.gridMode {
+		width, height = m.coverFrame(m.contentSize())
+	}
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	m.prerenderGen.Add(1)
+	generation := m.prerenderGen.Load()
+	items := append([]service.MediaItem(nil), m.items...)
+
+	return func() tea.Msg {
+		jobs := make(chan service.MediaItem)
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		rendered := 0
+
+		for range prerenderWorkers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for item := range jobs {
+					urls := coverURLs(item)
+					if len(urls) == 0 {
+						continue
+					}
+					RenderImage(context.Background(), urls, width, height)
+					mu.Lock()
+					rendered++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		for _, item := range items {
+			if m.prerenderGen.Load() != generation {
+				break
+			}
+			jobs <- item
+		}
+		close(jobs)
+		wg.Wait()
+
+		logging.UI("Covers pre-rendered", "count", rendered, "of", len(items))
+		return prerenderDoneMsg{generation: generation, rendered: rendered}
+	}
+}
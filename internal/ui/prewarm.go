@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"ember/internal/logging"
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	prewarmIdle     = 30 * time.Second
+	prewarmInterval = 15 * time.Second
+	prewarmDelay    = 500 * time.Millisecond
+	prewarmPages    = 2
+)
+
+type prewarmTickMsg struct{}
+
+type prewarmDoneMsg struct {
+	generation int64
+	warmed     int
+}
+
+func tickPrewarm() tea.Cmd {
+	return tea.Tick(prewarmInterval, func(time.Time) tea.Msg {
+		return prewarmTickMsg{}
+	})
+}
+
+func (m *Model) noteInput() {
+	m.lastInput = time.Now()
+	m.prewarmDone = false
+	if m.prewarming {
+		m.prewarmGen.Add(1)
+		m.prewarming = false
+	}
+}
+
+func (m *Model) maybePrewarm() tea.Cmd {
+	if !m.svc.PrewarmImages() || m.prewarming || m.prewarmDone || m.mini || CurrentImageQuality() == ImageQualityOff {
+		return nil
+	}
+	if m.state != StateBrowsing || m.nowPlaying != "" || m.svc.Offline() {
+		return nil
+	}
+	if time.Since(m.lastInput) < prewarmIdle {
+		return nil
+	}
+
+	width, height := gridThumbWidth, gridThumbHeight
+	if !m.gridMode {
+		width, height = m.coverFrame(m.contentSize())
+	}
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	m.prewarming = true
+	generation := m.prewarmGen.Add(1)
+	view, page, pageSize, filter := m.view, m.page, m.pageSize, m.itemFilter
+
+	return func() tea.Msg {
+		var items []service.MediaItem
+		if list, err := m.svc.GetFavorites(50); err == nil {
+			items = append(items, list.Items...)
+		}
+		if view.mode == viewItems && view.parentID != "" {
+			for next := page + 1; next <= page+prewarmPages; next++ {
+				list, err := m.svc.GetItems(view.parentID, next, pageSize, filter)
+				if err != nil || len(list.Items) == 0 {
+					break
+				}
+				items = append(items, list.Items...)
+			}
+		}
+
+		logging.UI("Pre-warm started", "items", len(items))
+		warmed := 0
+		for i, item := range items {
+			if m.prewarmGen.Load() != generation {
+				logging.UI("Pre-warm interrupted", "warmed", warmed, "of", len(items))
+				return prewarmDoneMsg{generation: generation, warmed: warmed}
+			}
+			urls := coverURLs(item)
+			if len(urls) == 0 {
+				continue
+			}
+			RenderImage(context.Background(), urls, width, height)
+			warmed++
+			if warmed%10 == 0 || i == len(items)-1 {
+				logging.UI("Pre-warm progress", "warmed", warmed, "of", len(items))
+			}
+			time.Sleep(prewarmDelay)
+		}
+
+		logging.UI("Pre-warm finished", "warmed", warmed, "of", len(items))
+		return prewarmDoneMsg{generation: generation, warmed: warmed}
+	}
+}
+
+func (m *Model) applyPrewarmDone(msg prewarmDoneMsg) {
+	if msg.generation != m.prewarmGen.Load() {
+		return
+	}
+	m.prewarming = false
+	m.prewarmDone = true
+}
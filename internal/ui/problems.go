@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Problem is a failed startup check. Fatal problems stop Ember; the others
+// can be acknowledged and the TUI starts anyway.
+type Problem struct {
+	Check   string
+	Message string
+	Hint    string
+	Fatal   bool
+}
+
+type problemsModel struct {
+	problems []Problem
+	fatal    bool
+	proceed  bool
+	width    int
+	height   int
+}
+
+// ShowProblems renders every startup problem on one screen and reports
+// whether the user chose to continue.
+func ShowProblems(problems []Problem) (bool, error) {
+	applyTerminalCaps(DetectTerminal())
+	model := &problemsModel{problems: problems}
+	for _, p := range problems {
+		model.fatal = model.fatal || p.Fatal
+	}
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return false, err
+	}
+	return model.proceed, nil
+}
+
+func (m *problemsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *problemsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "enter", "c":
+			if !m.fatal {
+				m.proceed = true
+				return m, tea.Quit
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *problemsModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Ember cannot start cleanly")
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	checkStyle := lipgloss.NewStyle().Bold(true).Width(10)
+	textWidth := max(min(m.width-20, 70), 30)
+
+	var lines []string
+	for _, p := range m.problems {
+		mark, color := "!", lipgloss.Color("214")
+		if p.Fatal {
+			mark, color = "✗", lipgloss.Color("196")
+		}
+		style := lipgloss.NewStyle().Foreground(color)
+		message := lipgloss.NewStyle().Width(textWidth).Render(p.Message)
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, style.Render(mark+" "), style.Inherit(checkStyle).Render(p.Check), message))
+		if p.Hint != "" {
+			hint := dimStyle.Width(textWidth).Render("→ " + p.Hint)
+			lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, strings.Repeat(" ", 12), hint))
+		}
+		lines = append(lines, "")
+	}
+
+	footer := "[enter] continue anyway  [q] quit"
+	if m.fatal {
+		footer = "Fix the problems marked ✗ and start Ember again.  [q] quit"
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	view := lipgloss.JoinVertical(lipgloss.Left, title, content, dimStyle.Render(footer))
+	if m.width > 0 {
+		view = lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, view)
+	}
+	return asciiFallback(view)
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type queuedMsg struct {
+	name  string
+	added int
+	err   error
+}
+
+func (m *Model) enqueue(item service.MediaItem) tea.Cmd {
+	return func() tea.Msg {
+		added, err := m.svc.Enqueue(item)
+		return queuedMsg{name: item.Name, added: added, err: err}
+	}
+}
+
+func (m *Model) applyQueued(msg queuedMsg) {
+	switch {
+	case msg.err != nil:
+		m.status = "Queue error: " + msg.err.Error()
+	case msg.added == 0:
+		m.status = msg.name + " is already queued"
+	default:
+		m.status = fmt.Sprintf("Queued %d item(s) from %s (%d in queue)", msg.added, msg.name, len(m.svc.Queue()))
+	}
+}
+
+type exportedMsg struct {
+	result service.ExportResult
+	err    error
+}
+
+func (m *Model) exportM3U(item service.MediaItem) tea.Cmd {
+	m.status = "Exporting " + item.Name + "..."
+	return func() tea.Msg {
+		result, err := m.svc.ExportM3U(item)
+		return exportedMsg{result: result, err: err}
+	}
+}
+
+func (m *Model) exportQueue() tea.Cmd {
+	m.status = "Exporting queue..."
+	return func() tea.Msg {
+		result, err := m.svc.ExportQueueM3U()
+		return exportedMsg{result: result, err: err}
+	}
+}
+
+func (m *Model) applyExported(msg exportedMsg) {
+	if msg.err != nil {
+		m.status = "Export failed: " + msg.err.Error()
+		if msg.result.Skipped > 0 {
+			m.status += fmt.Sprintf(" (%d skipped)", msg.result.Skipped)
+		}
+		return
+	}
+	m.status = fmt.Sprintf("Exported %d item(s) to %s", msg.result.Written, msg.result.Path)
+	if msg.result.Skipped > 0 {
+		m.status += fmt.Sprintf(", skipped %d unavailable", msg.result.Skipped)
+	}
+}
+
+func (m *Model) openQueue() (tea.Model, tea.Cmd) {
+	m.queueItems = m.svc.Queue()
+	m.queueCursor = min(m.queueCursor, max(len(m.queueItems)-1, 0))
+	m.state = StateQueue
+	return m, nil
+}
+
+func (m *Model) handleQueueKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "E":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "up", "k":
+		if m.queueCursor > 0 {
+			m.queueCursor--
+		}
+
+	case "down", "j":
+		if m.queueCursor < len(m.queueItems)-1 {
+			m.queueCursor++
+		}
+
+	case "K", "shift+up":
+		m.queueCursor = m.svc.MoveQueued(m.queueCursor, -1)
+		m.queueItems = m.svc.Queue()
+
+	case "J", "shift+down":
+		m.queueCursor = m.svc.MoveQueued(m.queueCursor, 1)
+		m.queueItems = m.svc.Queue()
+
+	case "d", "delete":
+		m.svc.RemoveQueued(m.queueCursor)
+		m.queueItems = m.svc.Queue()
+		m.queueCursor = min(m.queueCursor, max(len(m.queueItems)-1, 0))
+
+	case "C":
+		m.svc.ClearQueue()
+		m.queueItems = nil
+		m.queueCursor = 0
+		m.status = "Queue cleared"
+
+	case "M":
+		return m, m.exportQueue()
+
+	case "enter", "p":
+		if m.queueCursor >= len(m.queueItems) {
+			return m, nil
+		}
+		m.state = StateBrowsing
+		m.status = "Loading queue..."
+		return m, m.playQueue(m.queueCursor)
+	}
+
+	return m, nil
+}
+
+func (m *Model) renderQueue() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render(fmt.Sprintf("Queue (%d)", len(m.queueItems)))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	var lines []string
+	for i, item := range m.queueItems {
+		style := dimStyle
+		prefix := "  "
+		if i == m.queueCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+			prefix = "> "
+		}
+		name := item.Name
+		if item.SeriesName != "" {
+			name = item.SeriesName + " - " + item.Name
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s%2d. %s", prefix, i+1, truncateText(name, 60))))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, dimStyle.Render("Queue is empty; press e on an episode, movie or season to add it"))
+	}
+
+	hint := dimStyle.MarginTop(1).Render("[↑↓] select  [J/K] move  [d] remove  [C] clear  [M] export m3u8  [enter] play from here  [esc] back")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
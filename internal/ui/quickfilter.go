@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleJumpKey finishes a `g` prefix: a letter or digit moves to the next
+// loaded title starting with it, enter opens the season picker instead.
+func (m *Model) handleJumpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.jumpPending = false
+	m.status = ""
+	key := msg.String()
+
+	if key == "enter" {
+		if item, ok := m.currentItem(); ok && (item.Type == "Episode" || item.Type == "Season" || item.Type == "Series") {
+			return m, m.openSeasonPicker(item)
+		}
+		m.status = "Season picker needs a series, season or episode"
+		return m, nil
+	}
+
+	r, size := utf8.DecodeRuneInString(key)
+	if size != len(key) || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+		return m, nil
+	}
+	for step := 1; step <= len(m.items); step++ {
+		i := (m.cursor + step) % len(m.items)
+		if first, _ := utf8.DecodeRuneInString(m.items[i].Name); unicode.ToLower(first) == unicode.ToLower(r) {
+			return m.moveCursor(i - m.cursor)
+		}
+	}
+	m.status = fmt.Sprintf("No loaded title starts with %q", string(r))
+	return m, nil
+}
+
+func (m *Model) openQuickFilter() (tea.Model, tea.Cmd) {
+	if m.quickBase == nil {
+		if len(m.items) == 0 {
+			return m, nil
+		}
+		m.quickBase = m.items
+	}
+	m.quickFilter = textinput.New()
+	m.quickFilter.Prompt = "filter> "
+	m.quickFilter.Placeholder = "type to narrow this page"
+	m.quickFilter.CharLimit = 60
+	m.quickFilter.Width = 40
+	m.quickFilter.SetValue(m.quickQuery)
+	m.quickFilter.CursorEnd()
+	return m, m.quickFilter.Focus()
+}
+
+func (m *Model) handleQuickFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.clearQuickFilter()
+		return m, m.loadVisibleImages()
+
+	case "enter":
+		m.quickFilter.Blur()
+		if m.quickQuery == "" {
+			m.clearQuickFilter()
+		}
+		return m, nil
+
+	case "up":
+		return m.moveCursor(-1)
+
+	case "down":
+		return m.moveCursor(1)
+	}
+
+	var cmd tea.Cmd
+	m.quickFilter, cmd = m.quickFilter.Update(msg)
+	if query := strings.TrimSpace(m.quickFilter.Value()); query != m.quickQuery {
+		m.quickQuery = query
+		m.items = fuzzyFilter(m.quickBase, query)
+		m.cursor = 0
+		return m, tea.Batch(cmd, m.loadVisibleImages())
+	}
+	return m, cmd
+}
+
+// clearQuickFilter restores the full list with the filtered selection
+// still selected. Anything that leaves or reloads the view calls it first.
+func (m *Model) clearQuickFilter() {
+	m.quickFilter.Blur()
+	if m.quickBase == nil {
+		return
+	}
+	selected, ok := m.currentItem()
+	m.items = m.quickBase
+	m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+	if ok {
+		for i, item := range m.items {
+			if item.ID == selected.ID {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.quickBase = nil
+	m.quickQuery = ""
+}
+
+func (m *Model) quickFilterLine() string {
+	count := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(fmt.Sprintf("  %d of %d", len(m.items), len(m.quickBase)))
+	return m.quickFilter.View() + count
+}
+
+func fuzzyFilter(items []service.MediaItem, query string) []service.MediaItem {
+	if query == "" {
+		return items
+	}
+	type match struct {
+		item  service.MediaItem
+		score int
+	}
+	var matches []match
+	for _, item := range items {
+		if score, ok := fuzzyScore(query, item.Name+" "+item.SeriesName); ok {
+			matches = append(matches, match{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	out := make([]service.MediaItem, len(matches))
+	for i, mt := range matches {
+		out[i] = mt.item
+	}
+	return out
+}
+
+// fuzzyScore matches query as a case-insensitive subsequence of text.
+// Runs of consecutive characters and matches at word starts score higher,
+// so "dn" ranks "Dune" above "Hidden".
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(text))
+	score, qi, run := 0, 0, 0
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			run = 0
+			continue
+		}
+		run++
+		score += run
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		qi++
+	}
+	return score, qi == len(q)
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"slices"
+	"sort"
+	"sync"
+
+	"ember/internal/config"
+)
+
+type ImageRenderer interface {
+	Name() string
+	Render(img image.Image, width, height int) string
+}
+
+// Image qualities trade poster detail for speed. Renderers read the
+// current one while drawing; off skips fetching covers altogether.
+const (
+	ImageQualityOff  = config.ImageQualityOff
+	ImageQualityLow  = config.ImageQualityLow
+	ImageQualityHigh = config.ImageQualityHigh
+)
+
+var ImageQualities = config.ImageQualities
+
+var (
+	renderers      = make(map[string]ImageRenderer)
+	activeRenderer ImageRenderer
+	imageQuality   = ImageQualityHigh
+	rendererMu     sync.RWMutex
+)
+
+func registerRenderer(r ImageRenderer) {
+	rendererMu.Lock()
+	defer rendererMu.Unlock()
+	renderers[r.Name()] = r
+}
+
+func ImageRendererNames() []string {
+	rendererMu.RLock()
+	defer rendererMu.RUnlock()
+
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func SetImageRenderer(name string) error {
+	rendererMu.Lock()
+	r, ok := renderers[name]
+	if ok {
+		activeRenderer = r
+	}
+	rendererMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("unknown image renderer: %s", name)
+	}
+	ClearImageCache()
+	return nil
+}
+
+func currentRenderer() ImageRenderer {
+	rendererMu.RLock()
+	defer rendererMu.RUnlock()
+
+	if activeRenderer != nil {
+		return activeRenderer
+	}
+	if r, ok := renderers[defaultRenderer]; ok {
+		return r
+	}
+	return renderers[halfblockRendererName]
+}
+
+func CurrentImageRenderer() string {
+	return currentRenderer().Name()
+}
+
+func SetImageQuality(quality string) error {
+	if !slices.Contains(ImageQualities, quality) {
+		return fmt.Errorf("unknown image quality: %s", quality)
+	}
+	rendererMu.Lock()
+	imageQuality = quality
+	rendererMu.Unlock()
+	ClearImageCache()
+	return nil
+}
+
+func CurrentImageQuality() string {
+	rendererMu.RLock()
+	defer rendererMu.RUnlock()
+	return imageQuality
+}
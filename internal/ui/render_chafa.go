@@ -0,0 +1,128 @@
+//go:build !nochafa
+
+package ui
+
+import (
+	"image"
+	"strings"
+
+	"github.com/muesli/termenv"
+	chafa "github.com/ploMP4/chafa-go"
+	"golang.org/x/image/draw"
+)
+
+const defaultRenderer = "chafa"
+
+type chafaRenderer struct {
+	name string
+	mode chafa.PixelMode
+}
+
+func init() {
+	registerRenderer(chafaRenderer{name: "chafa", mode: chafa.CHAFA_PIXEL_MODE_SYMBOLS})
+	registerRenderer(chafaRenderer{name: "kitty", mode: chafa.CHAFA_PIXEL_MODE_KITTY})
+	registerRenderer(chafaRenderer{name: "sixel", mode: chafa.CHAFA_PIXEL_MODE_SIXELS})
+}
+
+func (r chafaRenderer) Name() string {
+	return r.name
+}
+
+func (r chafaRenderer) Render(img image.Image, width, height int) string {
+	cellWidth, cellHeight := int32(8), int32(8)
+	if r.mode != chafa.CHAFA_PIXEL_MODE_SYMBOLS {
+		cellWidth, cellHeight = 10, 20
+	}
+	pixels := scaleToCells(img, width*int(cellWidth), height*int(cellHeight))
+	imgWidth := pixels.Rect.Dx()
+	imgHeight := pixels.Rect.Dy()
+
+	ccfg := chafa.CanvasConfigNew()
+	defer chafa.CanvasConfigUnref(ccfg)
+
+	chafa.CanvasConfigSetGeometry(ccfg, int32(width), int32(height))
+	chafa.CanvasConfigSetCanvasMode(ccfg, chafaCanvasMode())
+	chafa.CanvasConfigSetPixelMode(ccfg, r.mode)
+	chafa.CanvasConfigSetCellGeometry(ccfg, cellWidth, cellHeight)
+
+	// Low quality matches colors in plain RGB, skips preprocessing and
+	// only tries half blocks, which is several times cheaper per cell.
+	symbols := chafa.CHAFA_SYMBOL_TAG_BLOCK | chafa.CHAFA_SYMBOL_TAG_HALF | chafa.CHAFA_SYMBOL_TAG_QUAD
+	if CurrentImageQuality() == ImageQualityLow {
+		symbols = chafa.CHAFA_SYMBOL_TAG_HALF
+		chafa.CanvasConfigSetColorSpace(ccfg, chafa.CHAFA_COLOR_SPACE_RGB)
+		chafa.CanvasConfigSetPreprocessingEnabled(ccfg, false)
+		chafa.CanvasConfigSetWorkFactor(ccfg, 0.1)
+	} else {
+		chafa.CanvasConfigSetColorSpace(ccfg, chafa.CHAFA_COLOR_SPACE_DIN99D)
+		chafa.CanvasConfigSetPreprocessingEnabled(ccfg, true)
+		chafa.CanvasConfigSetWorkFactor(ccfg, 1.0)
+	}
+
+	symbolMap := chafa.SymbolMapNew()
+	defer chafa.SymbolMapUnref(symbolMap)
+	chafa.SymbolMapAddByTags(symbolMap, symbols)
+	chafa.CanvasConfigSetSymbolMap(ccfg, symbolMap)
+
+	canvas := chafa.CanvasNew(ccfg)
+	defer chafa.CanvasUnRef(canvas)
+
+	chafa.CanvasDrawAllPixels(
+		canvas,
+		chafa.CHAFA_PIXEL_RGBA8_UNASSOCIATED,
+		pixels.Pix,
+		int32(imgWidth),
+		int32(imgHeight),
+		int32(pixels.Stride),
+	)
+
+	termDb := chafa.TermDbGetDefault()
+	termInfo := chafa.TermDbGetFallbackInfo(termDb)
+	defer chafa.TermInfoUnref(termInfo)
+
+	gstr := chafa.CanvasPrint(canvas, termInfo)
+	result := strings.TrimSuffix(gstr.String(), "\n")
+
+	if r.mode != chafa.CHAFA_PIXEL_MODE_SYMBOLS {
+		result += strings.Repeat("\n", max(0, height-1))
+	}
+	return result
+}
+
+func chafaCanvasMode() chafa.CanvasMode {
+	switch colorProfile {
+	case termenv.ANSI256:
+		return chafa.CHAFA_CANVAS_MODE_INDEXED_256
+	case termenv.ANSI:
+		return chafa.CHAFA_CANVAS_MODE_INDEXED_16
+	case termenv.Ascii:
+		return chafa.CHAFA_CANVAS_MODE_FGBG
+	}
+	return chafa.CHAFA_CANVAS_MODE_TRUECOLOR
+}
+
+// scaleToCells converts img to unpremultiplied RGBA for chafa, shrinking
+// it to at most maxWidth x maxHeight pixels first. Chafa never needs more
+// than a few pixels per cell, and a full-size poster costs far more to
+// convert and hand over than the cells it ends up in.
+func scaleToCells(img image.Image, maxWidth, maxHeight int) *image.NRGBA {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxWidth || height > maxHeight {
+		scale := min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+		width = max(1, int(float64(width)*scale))
+		height = max(1, int(float64(height)*scale))
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if width == bounds.Dx() && height == bounds.Dy() {
+		draw.Draw(dst, dst.Rect, img, bounds.Min, draw.Src)
+		return dst
+	}
+	scaler := draw.Scaler(draw.CatmullRom)
+	if CurrentImageQuality() == ImageQualityLow {
+		scaler = draw.ApproxBiLinear
+	}
+	scaler.Scale(dst, dst.Rect, img, bounds, draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+const halfblockRendererName = "halfblocks"
+
+type halfblockRenderer struct{}
+
+func init() {
+	registerRenderer(halfblockRenderer{})
+}
+
+func (halfblockRenderer) Name() string {
+	return halfblockRendererName
+}
+
+func (halfblockRenderer) Render(img image.Image, width, height int) string {
+	bounds := img.Bounds()
+	if bounds.Empty() || width <= 0 || height <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	rows := height * 2
+	for y := 0; y < height; y++ {
+		var prevTop, prevBottom [3]uint8
+		for x := 0; x < width; x++ {
+			top := averageColor(img, bounds, x, 2*y, width, rows)
+			bottom := averageColor(img, bounds, x, 2*y+1, width, rows)
+			if asciiOnly {
+				bottom = [3]uint8{uint8((int(top[0]) + int(bottom[0])) / 2), uint8((int(top[1]) + int(bottom[1])) / 2), uint8((int(top[2]) + int(bottom[2])) / 2)}
+				if x == 0 || bottom != prevBottom {
+					b.WriteString(colorSequence(bottom, true))
+				}
+				b.WriteByte(' ')
+				prevBottom = bottom
+				continue
+			}
+			if x == 0 || top != prevTop {
+				b.WriteString(colorSequence(top, false))
+			}
+			if x == 0 || bottom != prevBottom {
+				b.WriteString(colorSequence(bottom, true))
+			}
+			b.WriteString("▀")
+			prevTop, prevBottom = top, bottom
+		}
+		b.WriteString("\x1b[0m")
+		if y < height-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+func colorSequence(c [3]uint8, background bool) string {
+	if colorProfile == termenv.TrueColor {
+		if background {
+			return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c[0], c[1], c[2])
+		}
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c[0], c[1], c[2])
+	}
+	seq := colorProfile.Color(fmt.Sprintf("#%02x%02x%02x", c[0], c[1], c[2])).Sequence(background)
+	if seq == "" {
+		return ""
+	}
+	return "\x1b[" + seq + "m"
+}
+
+func averageColor(img image.Image, bounds image.Rectangle, cx, cy, cols, rows int) [3]uint8 {
+	x0 := bounds.Min.X + cx*bounds.Dx()/cols
+	x1 := bounds.Min.X + (cx+1)*bounds.Dx()/cols
+	y0 := bounds.Min.Y + cy*bounds.Dy()/rows
+	y1 := bounds.Min.Y + (cy+1)*bounds.Dy()/rows
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+
+	var r, g, bl, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			pr, pg, pb, _ := img.At(x, y).RGBA()
+			r += uint64(pr >> 8)
+			g += uint64(pg >> 8)
+			bl += uint64(pb >> 8)
+			n++
+		}
+	}
+	return [3]uint8{uint8(r / n), uint8(g / n), uint8(bl / n)}
+}
@@ -0,0 +1,5 @@
+//go:build nochafa
+
+package ui
+
+const defaultRenderer = halfblockRendererName
@@ -1,17 +1,83 @@
 package ui
 
 import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"ember/internal/service"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
-func Run(svc *service.MediaService) error {
+const shutdownTimeout = 3 * time.Second
+
+type Options struct {
+	Mini     bool
+	Version  string
+	Renderer string
+	Quality  string
+	PageSize int
+	Accent   string
+	Keys     map[string]string
+}
+
+func Run(svc *service.MediaService, opts Options) error {
+	caps := DetectTerminal()
+	applyTerminalCaps(caps)
+	if opts.Renderer != "" {
+		if err := SetImageRenderer(opts.Renderer); err != nil {
+			return err
+		}
+	} else if name := svc.ImageRenderer(); name != "" {
+		_ = SetImageRenderer(name)
+	} else if !caps.Unicode {
+		_ = SetImageRenderer(halfblockRendererName)
+	}
+	if opts.Quality != "" {
+		if err := SetImageQuality(opts.Quality); err != nil {
+			return err
+		}
+	} else if quality := svc.ImageQuality(); quality != "" {
+		_ = SetImageQuality(quality)
+	}
+
+	events, unsubscribe := svc.Events().Subscribe(32)
+	defer unsubscribe()
+
+	if opts.Accent != "" && opts.Accent != "poster" {
+		fixedAccent = lipgloss.Color(opts.Accent)
+	}
+
+	model := New(svc)
+	model.mini = opts.Mini
+	if opts.PageSize > 0 {
+		model.pageSize = opts.PageSize
+	}
+	model.keymap = opts.Keys
+	model.events = events
+	model.version = opts.Version
+
 	p := tea.NewProgram(
-		New(svc),
+		model,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer func() {
+		signal.Stop(hangup)
+		close(hangup)
+	}()
+	go func() {
+		if _, ok := <-hangup; ok {
+			p.Quit()
+		}
+	}()
+
 	_, err := p.Run()
+	svc.Shutdown(shutdownTimeout)
 	return err
 }
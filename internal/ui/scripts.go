@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"ember/internal/script"
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type scriptsLoadedMsg struct {
+	engine *script.Engine
+	item   service.MediaItem
+	err    error
+}
+
+type scriptDoneMsg struct {
+	name   string
+	result string
+	err    error
+}
+
+func (m *Model) openScripts(item service.MediaItem) tea.Cmd {
+	return func() tea.Msg {
+		engine, err := script.Load(m.svc, script.Dir())
+		return scriptsLoadedMsg{engine: engine, item: item, err: err}
+	}
+}
+
+func (m *Model) applyScriptsLoaded(msg scriptsLoadedMsg) {
+	m.scripts = msg.engine
+	m.scriptItem = msg.item
+	m.scriptActions = msg.engine.Actions(msg.item)
+	m.scriptCursor = 0
+	m.scriptErr = ""
+	if msg.err != nil {
+		m.scriptErr = msg.err.Error()
+	}
+	m.state = StateScriptPicker
+}
+
+func (m *Model) handleScriptPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "x":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "up", "k":
+		if m.scriptCursor > 0 {
+			m.scriptCursor--
+		}
+
+	case "down", "j":
+		if m.scriptCursor < len(m.scriptActions)-1 {
+			m.scriptCursor++
+		}
+
+	case "enter":
+		if m.scriptCursor >= len(m.scriptActions) {
+			return m, nil
+		}
+		action := m.scriptActions[m.scriptCursor]
+		engine, item := m.scripts, m.scriptItem
+		m.state = StateBrowsing
+		m.status = "Running " + action.Name + "..."
+		return m, func() tea.Msg {
+			result, err := engine.Run(action, item)
+			return scriptDoneMsg{name: action.Name, result: result, err: err}
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) applyScriptDone(msg scriptDoneMsg) {
+	switch {
+	case msg.err != nil:
+		m.status = "Script error: " + msg.err.Error()
+	case msg.result != "":
+		m.status = msg.result
+	default:
+		m.status = msg.name + ": done"
+	}
+}
+
+func (m *Model) renderScriptPicker() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Scripts: " + truncateText(m.scriptItem.Name, 40))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	labelStyle := lipgloss.NewStyle().Width(32)
+
+	var lines []string
+	for i, action := range m.scriptActions {
+		style := dimStyle
+		prefix := "  "
+		if i == m.scriptCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+			prefix = "> "
+		}
+		lines = append(lines, style.Render(prefix+labelStyle.Render(truncateText(action.Name, 30))+action.File))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, dimStyle.Render("No actions for this item (scripts live in "+script.Dir()+")"))
+	}
+	if m.scriptErr != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Width(70).Render(m.scriptErr))
+	}
+
+	hint := dimStyle.MarginTop(1).Render("[↑↓] select  [enter] run  [esc] back")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
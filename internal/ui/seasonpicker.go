@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type seasonPickerMsg struct {
+	seriesID   string
+	seriesName string
+	seasons    []service.MediaItem
+	err        error
+}
+
+func (m *Model) openSeasonPicker(item service.MediaItem) tea.Cmd {
+	return func() tea.Msg {
+		if item.Type == "Series" {
+			list, err := m.svc.GetSeasons(item.ID)
+			if err != nil {
+				return seasonPickerMsg{err: err}
+			}
+			return seasonPickerMsg{seriesID: item.ID, seriesName: item.Name, seasons: list.Items}
+		}
+
+		list, seriesID, err := m.svc.ResolveSeries(item)
+		if err != nil {
+			return seasonPickerMsg{err: err}
+		}
+		return seasonPickerMsg{seriesID: seriesID, seriesName: item.SeriesName, seasons: list.Items}
+	}
+}
+
+func (m *Model) applySeasonPicker(msg seasonPickerMsg) {
+	if msg.err != nil {
+		m.status = "Cannot list seasons: " + msg.err.Error()
+		return
+	}
+	if len(msg.seasons) == 0 {
+		m.status = "No seasons"
+		return
+	}
+
+	m.seasonPicker = msg
+	m.seasonCursor = 0
+	for i, season := range msg.seasons {
+		if m.view.mode == viewEpisodes && season.ID == m.view.seasonID {
+			m.seasonCursor = i
+		}
+	}
+	m.state = StateSeasonPicker
+}
+
+func (m *Model) handleSeasonPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	seasons := m.seasonPicker.seasons
+	key := msg.String()
+
+	switch key {
+	case "q", "esc", "g":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "up", "k":
+		if m.seasonCursor > 0 {
+			m.seasonCursor--
+		}
+
+	case "down", "j":
+		if m.seasonCursor < len(seasons)-1 {
+			m.seasonCursor++
+		}
+
+	case "enter":
+		return m.jumpToPickedSeason(m.seasonCursor)
+
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= min(9, len(seasons)) {
+			return m.jumpToPickedSeason(n - 1)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) jumpToPickedSeason(idx int) (tea.Model, tea.Cmd) {
+	season := m.seasonPicker.seasons[idx]
+	seriesID := m.seasonPicker.seriesID
+
+	if m.view.mode != viewEpisodes || m.view.seriesID != seriesID {
+		m.pushNav()
+	}
+	m.view = viewState{mode: viewEpisodes, seriesID: seriesID, seasonID: season.ID}
+	m.page = 0
+	m.keepCursor = false
+	m.state = StateLoading
+	m.status = m.seasonPicker.seriesName + " / " + season.Name
+	return m, m.loadEpisodes(seriesID, season.ID)
+}
+
+func (m *Model) renderSeasonPicker() string {
+	name := m.seasonPicker.seriesName
+	if name == "" {
+		name = "Seasons"
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render(name)
+	labelStyle := lipgloss.NewStyle().Width(24)
+
+	lines := make([]string, len(m.seasonPicker.seasons))
+	for i, season := range m.seasonPicker.seasons {
+		key := " "
+		if i < 9 {
+			key = strconv.Itoa(i + 1)
+		}
+		progress := ""
+		if season.ChildCount > 0 {
+			unplayed := 0
+			if season.UserData != nil {
+				unplayed = season.UserData.UnplayedItemCount
+			}
+			progress = fmt.Sprintf("%d/%d watched", season.ChildCount-unplayed, season.ChildCount)
+		}
+
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+		if i == m.seasonCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+		}
+		lines[i] = style.Render(key + "  " + labelStyle.Render(truncateText(season.Name, 22)) + progress)
+	}
+
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).MarginTop(1).Render(
+		"[1-9] jump  [↑↓] select  [enter] open  [esc] back",
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
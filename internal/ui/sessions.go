@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type sessionsMsg struct {
+	sessions []service.SessionInfo
+	err      error
+}
+
+type sessionActionMsg struct {
+	status string
+	err    error
+}
+
+func (m *Model) loadSessions() tea.Cmd {
+	return func() tea.Msg {
+		sessions, err := m.svc.GetSessions()
+		return sessionsMsg{sessions: sessions, err: err}
+	}
+}
+
+func (m *Model) openSessions() (tea.Model, tea.Cmd) {
+	m.status = "Loading sessions..."
+	return m, m.loadSessions()
+}
+
+func (m *Model) applySessions(msg sessionsMsg) {
+	if msg.err != nil {
+		m.status = "Cannot list sessions: " + msg.err.Error()
+		return
+	}
+	m.sessions = msg.sessions
+	m.sessionCursor = min(m.sessionCursor, max(len(m.sessions)-1, 0))
+	if m.state != StateSessions {
+		m.status = ""
+	}
+	m.state = StateSessions
+}
+
+func (m *Model) applySessionAction(msg sessionActionMsg) tea.Cmd {
+	if msg.err != nil {
+		m.status = msg.err.Error()
+	} else {
+		m.status = msg.status
+	}
+	if m.state != StateSessions {
+		return nil
+	}
+	return m.loadSessions()
+}
+
+func (m *Model) handleSessionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "A":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "up", "k":
+		if m.sessionCursor > 0 {
+			m.sessionCursor--
+		}
+
+	case "down", "j":
+		if m.sessionCursor < len(m.sessions)-1 {
+			m.sessionCursor++
+		}
+
+	case "r":
+		return m, m.loadSessions()
+
+	case "s":
+		if m.sessionCursor >= len(m.sessions) {
+			return m, nil
+		}
+		sess := m.sessions[m.sessionCursor]
+		if sess.NowPlaying == "" {
+			m.status = sess.Device + " is not playing anything"
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			return sessionActionMsg{status: "Stopped playback on " + sess.Device, err: m.svc.StopSession(sess.ID)}
+		}
+
+	case "X":
+		return m, func() tea.Msg {
+			n, err := m.svc.StopAllSessions()
+			return sessionActionMsg{status: fmt.Sprintf("Stopped playback on %d device(s)", n), err: err}
+		}
+
+	case "L":
+		if m.sessionCursor >= len(m.sessions) {
+			return m, nil
+		}
+		sess := m.sessions[m.sessionCursor]
+		return m, func() tea.Msg {
+			return sessionActionMsg{status: "Signed out " + sess.Device, err: m.svc.LogoutSession(sess)}
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) renderSessions() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render(fmt.Sprintf("Active Sessions (%d)", len(m.sessions)))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	var lines []string
+	for i, sess := range m.sessions {
+		style := dimStyle
+		prefix := "  "
+		if i == m.sessionCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+			prefix = "> "
+		}
+		device := sess.Device + " (" + sess.Client + ")"
+		if sess.Current {
+			device += " - this device"
+		}
+		lines = append(lines, style.Render(prefix+truncateText(device, 60)))
+
+		playing := "idle"
+		if sess.NowPlaying != "" {
+			playing = fmt.Sprintf("%s at %s", truncateText(sess.NowPlaying, 40), formatDuration(sess.PositionSec))
+			if sess.Paused {
+				playing += ", paused"
+			}
+			if sess.PlayMethod != "" {
+				playing += ", " + sess.PlayMethod
+			}
+		}
+		lines = append(lines, dimStyle.Render("    "+playing))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, dimStyle.Render("No active sessions"))
+	}
+
+	hint := dimStyle.MarginTop(1).Render("[↑↓] select  [s] stop  [X] stop all other devices  [L] sign out device  [r] refresh  [esc] back")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
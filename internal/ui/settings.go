@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"ember/internal/player"
+	"ember/internal/service"
+	"ember/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type settingEntry struct {
+	label  string
+	value  func() string
+	adjust func(delta int)
+}
+
+var (
+	bitrateOptions       = []int{0, 40_000_000, 20_000_000, 12_000_000, 8_000_000, 4_000_000, 2_000_000, 1_000_000}
+	releaseWindowOptions = []int{30, 90, 180, 365}
+	playedOptions        = []int{-1, 85, 90, 92, 95, 98}
+	rewindOptions        = []int{-1, 5, 10, 15, 30}
+)
+
+func (m *Model) settingEntries() []settingEntry {
+	return []settingEntry{
+		{
+			label: "Max bitrate",
+			value: func() string { return formatBitrate(m.svc.MaxBitrate()) },
+			adjust: func(delta int) {
+				next := cycleOption(bitrateOptions, m.svc.MaxBitrate(), delta)
+				if err := m.svc.SetMaxBitrate(next); err != nil {
+					m.status = "Settings error: " + err.Error()
+					return
+				}
+				m.status = "Max bitrate: " + formatBitrate(next)
+			},
+		},
+		{
+			label: "Release window",
+			value: func() string { return fmt.Sprintf("%d days", m.svc.ReleaseWindowDays()) },
+			adjust: func(delta int) {
+				next := cycleOption(releaseWindowOptions, m.svc.ReleaseWindowDays(), delta)
+				m.svc.SetReleaseWindowDays(next)
+				m.status = fmt.Sprintf("Release window: %d days", next)
+			},
+		},
+		{
+			label: "Mark played at",
+			value: func() string { return formatPlayedThreshold(m.svc.PlayedThreshold()) },
+			adjust: func(delta int) {
+				next := cycleOption(playedOptions, m.svc.PlayedThreshold(), delta)
+				m.svc.SetPlayedThreshold(next)
+				m.status = "Mark played at: " + formatPlayedThreshold(next)
+			},
+		},
+		{
+			label: "Resume rewind",
+			value: func() string { return formatResumeRewind(m.svc.ResumeRewind()) },
+			adjust: func(delta int) {
+				next := cycleOption(rewindOptions, m.svc.ResumeRewind(), delta)
+				m.svc.SetResumeRewind(next)
+				m.status = "Resume rewind: " + formatResumeRewind(next)
+			},
+		},
+		{
+			label: "Image renderer",
+			value: CurrentImageRenderer,
+			adjust: func(delta int) {
+				next := cycleString(ImageRendererNames(), CurrentImageRenderer(), delta)
+				if err := SetImageRenderer(next); err != nil {
+					m.status = "Settings error: " + err.Error()
+					return
+				}
+				m.svc.SetImageRenderer(next)
+				m.coverCache.Clear()
+				m.status = "Image renderer: " + next
+			},
+		},
+		{
+			label: "Image quality",
+			value: CurrentImageQuality,
+			adjust: func(delta int) {
+				next := cycleString(ImageQualities, CurrentImageQuality(), delta)
+				if err := SetImageQuality(next); err != nil {
+					m.status = "Settings error: " + err.Error()
+					return
+				}
+				m.svc.SetImageQuality(next)
+				m.coverCache.Clear()
+				m.status = "Image quality: " + next
+			},
+		},
+		{
+			label: "Hide watched",
+			value: func() string { return onOff(m.svc.HideWatched()) },
+			adjust: func(int) {
+				m.svc.SetHideWatched(!m.svc.HideWatched())
+				m.status = "Hide watched by default: " + onOff(m.svc.HideWatched())
+			},
+		},
+		{
+			label: "Idle pre-warm",
+			value: func() string { return onOff(m.svc.PrewarmImages()) },
+			adjust: func(int) {
+				m.svc.SetPrewarmImages(!m.svc.PrewarmImages())
+				m.status = "Idle poster pre-warm: " + onOff(m.svc.PrewarmImages())
+			},
+		},
+		{
+			label: "Storage",
+			value: m.svc.StorageBackend,
+			adjust: func(delta int) {
+				next := cycleString(storage.Backends, m.svc.StorageBackend(), delta)
+				if err := m.svc.SetStorageBackend(next); err != nil {
+					m.status = "Settings error: " + err.Error()
+					return
+				}
+				m.status = "Storage backend: " + next
+			},
+		},
+		{
+			label: "Analytics",
+			value: func() string { return onOff(m.svc.Analytics()) },
+			adjust: func(int) {
+				m.svc.SetAnalytics(!m.svc.Analytics())
+				m.status = "Local analytics: " + onOff(m.svc.Analytics())
+			},
+		},
+		{
+			label: "Subtitle langs",
+			value: func() string { return formatLangs(m.svc.SubtitleLangs()) },
+		},
+		{
+			label: "Audio langs",
+			value: func() string { return formatLangs(m.svc.AudioLangs()) },
+		},
+		{
+			label: "Forced subs only",
+			value: func() string { return onOff(m.svc.ForcedSubsOnly()) },
+			adjust: func(int) {
+				m.svc.SetForcedSubsOnly(!m.svc.ForcedSubsOnly())
+				m.status = "Forced subtitles only: " + onOff(m.svc.ForcedSubsOnly())
+			},
+		},
+		{
+			label: "MPV args",
+			value: func() string { return formatMPVArgs(m.svc.MPVArgs()) },
+		},
+		{
+			label: "Access window",
+			value: func() string { return service.FormatAccessWindows(m.svc.AccessWindows()) },
+		},
+	}
+}
+
+func (m *Model) handleSettingsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := m.settingEntries()
+
+	switch msg.String() {
+	case "q", "esc", "o":
+		m.state = StateBrowsing
+		return m, m.loadVisibleImages()
+
+	case "up", "k":
+		if m.settingsCursor > 0 {
+			m.settingsCursor--
+		}
+
+	case "down", "j":
+		if m.settingsCursor < len(entries)-1 {
+			m.settingsCursor++
+		}
+
+	case "left", "h":
+		if m.settingsCursor < len(entries) && entries[m.settingsCursor].adjust != nil {
+			entries[m.settingsCursor].adjust(-1)
+		}
+
+	case "right", "l", "enter":
+		if m.settingsCursor < len(entries) && entries[m.settingsCursor].adjust != nil {
+			entries[m.settingsCursor].adjust(1)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) renderSettings() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Settings")
+	labelStyle := lipgloss.NewStyle().Width(18)
+
+	entries := m.settingEntries()
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+		if i == m.settingsCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+		}
+		value := entry.value()
+		if entry.adjust != nil {
+			value = "< " + value + " >"
+		}
+		lines[i] = style.Render(labelStyle.Render(entry.label) + value)
+	}
+
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).MarginTop(1).Render(
+		"[↑↓] select  [←→] change  [esc] back",
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
+
+func cycleOption(options []int, current, delta int) int {
+	idx := 0
+	for i, opt := range options {
+		if opt == current {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + delta + len(options)) % len(options)
+	return options[idx]
+}
+
+func onOff(value bool) string {
+	if value {
+		return "On"
+	}
+	return "Off"
+}
+
+func formatBitrate(bps int) string {
+	if bps <= 0 {
+		return "Unlimited"
+	}
+	return fmt.Sprintf("%d Mbps", bps/1_000_000)
+}
+
+func formatResumeRewind(sec int) string {
+	if sec < 0 {
+		return "Off"
+	}
+	return fmt.Sprintf("%ds", sec)
+}
+
+func formatPlayedThreshold(percent int) string {
+	if percent < 0 {
+		return "Off"
+	}
+	return fmt.Sprintf("%d%%", percent)
+}
+
+func formatLangs(langs []string) string {
+	if len(langs) == 0 {
+		return "any"
+	}
+	return truncateText(strings.Join(langs, ","), 50)
+}
+
+func formatMPVArgs(args player.Args) string {
+	var parts []string
+	if args.Profile != "" {
+		parts = append(parts, "--profile="+args.Profile)
+	}
+	parts = append(parts, args.Extra...)
+	if len(parts) == 0 {
+		return "none"
+	}
+	return truncateText(strings.Join(parts, " "), 50)
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) shuffleTarget() (parentID, title string, ok bool) {
+	item, hasItem := m.currentItem()
+	if hasItem {
+		switch item.Type {
+		case "Series", "CollectionFolder", "Folder", "BoxSet":
+			return item.ID, item.Name, true
+		}
+	}
+
+	switch m.view.mode {
+	case viewFavorites:
+		return "", "Favorites", true
+	case viewSeasons, viewEpisodes:
+		title = "Series"
+		if hasItem && item.SeriesName != "" {
+			title = item.SeriesName
+		}
+		return m.view.seriesID, title, m.view.seriesID != ""
+	case viewItems:
+		title = "Library"
+		if m.currentLib != nil {
+			title = m.currentLib.Name
+		}
+		return m.view.parentID, title, m.view.parentID != ""
+	}
+	return "", "", false
+}
+
+func (m *Model) playShuffle(unwatchedOnly bool) (tea.Model, tea.Cmd) {
+	parentID, title, ok := m.shuffleTarget()
+	if !ok {
+		m.status = "Shuffle works on a library, series or Favorites"
+		return m, nil
+	}
+
+	m.status = "Shuffling " + title + "..."
+	if unwatchedOnly {
+		m.status = "Shuffling unwatched in " + title + "..."
+	}
+	m.nowPlaying = "Shuffle: " + title
+
+	return m, tea.Batch(tickPlayback(), func() tea.Msg {
+		plan, err := m.svc.BuildShufflePlayback(parentID, title, unwatchedOnly)
+		if err != nil {
+			return playDoneMsg{err: err}
+		}
+		return m.playPlan(plan, nil)
+	})
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"fmt"
+
+	"ember/internal/logging"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type reportsSyncedMsg struct {
+	synced int
+	err    error
+}
+
+func (m *Model) syncPendingReports() tea.Cmd {
+	if m.syncingReports || m.svc.PendingReportCount() == 0 {
+		return nil
+	}
+	m.syncingReports = true
+	return func() tea.Msg {
+		synced, err := m.svc.SyncPendingReports()
+		return reportsSyncedMsg{synced: synced, err: err}
+	}
+}
+
+func (m *Model) applyReportsSynced(msg reportsSyncedMsg) {
+	m.syncingReports = false
+	logging.UI("Pending reports synced", "synced", msg.synced, "error", msg.err)
+	if msg.synced > 0 {
+		m.status = fmt.Sprintf("Synced %d pending playback report(s)", msg.synced)
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+type TerminalCaps struct {
+	Term     string
+	Colors   termenv.Profile
+	Unicode  bool
+	Graphics string
+}
+
+var (
+	colorProfile = termenv.TrueColor
+	asciiOnly    bool
+)
+
+var asciiReplacer = strings.NewReplacer(
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	"─", "-", "━", "=", "│", "|", "┃", "|",
+	"←", "<", "→", ">", "↑", "^", "↓", "v", "↵", "<",
+	"‹", "<", "›", ">", "≤", "<", "·", ".", "•", "*",
+	"★", "*", "✓", "v", "✗", "x", "…", "~", "▀", " ",
+)
+
+func DetectTerminal() TerminalCaps {
+	return TerminalCaps{
+		Term:     os.Getenv("TERM"),
+		Colors:   lipgloss.ColorProfile(),
+		Unicode:  unicodeTerminal(),
+		Graphics: graphicsProtocol(),
+	}
+}
+
+func applyTerminalCaps(caps TerminalCaps) {
+	colorProfile = caps.Colors
+	asciiOnly = !caps.Unicode
+}
+
+func asciiFallback(view string) string {
+	if !asciiOnly {
+		return view
+	}
+	return asciiReplacer.Replace(view)
+}
+
+func unicodeTerminal() bool {
+	switch os.Getenv("EMBER_ASCII") {
+	case "1", "true":
+		return false
+	case "0", "false":
+		return true
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	if os.Getenv("TERM") == "linux" {
+		return false
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		value := strings.ToLower(os.Getenv(key))
+		if value == "" {
+			continue
+		}
+		return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+	}
+	return true
+}
+
+func graphicsProtocol() string {
+	term := strings.ToLower(os.Getenv("TERM"))
+	program := strings.ToLower(os.Getenv("TERM_PROGRAM"))
+	switch {
+	case strings.HasPrefix(term, "screen"), strings.HasPrefix(term, "tmux"):
+		return ""
+	case os.Getenv("KITTY_WINDOW_ID") != "", strings.Contains(term, "kitty"),
+		program == "ghostty", program == "wezterm":
+		return "kitty"
+	case strings.Contains(term, "foot"), strings.Contains(term, "mlterm"), strings.Contains(term, "sixel"),
+		os.Getenv("WT_SESSION") != "":
+		return "sixel"
+	}
+	return ""
+}
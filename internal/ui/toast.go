@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	toastTTL      = 4 * time.Second
+	errorToastTTL = 10 * time.Second
+	maxToasts     = 4
+)
+
+// toast is a short-lived notification. Unlike m.status, which describes
+// what is going on right now ("Loading...", "Marking 3/12..."), toasts
+// report results, stack up and expire on their own.
+type toast struct {
+	id   int
+	text string
+	err  bool
+}
+
+type toastExpiredMsg struct {
+	id int
+}
+
+func (m *Model) notify(text string) tea.Cmd {
+	return m.pushToast(text, false, toastTTL)
+}
+
+// notifyError keeps errors up longer than other toasts so they can be read.
+func (m *Model) notifyError(text string) tea.Cmd {
+	return m.pushToast(text, true, errorToastTTL)
+}
+
+func (m *Model) pushToast(text string, isErr bool, ttl time.Duration) tea.Cmd {
+	m.toastSeq++
+	id := m.toastSeq
+	m.toasts = append(m.toasts, toast{id: id, text: text, err: isErr})
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+	return tea.Tick(ttl, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+func (m *Model) expireToast(id int) {
+	for i, t := range m.toasts {
+		if t.id == id {
+			m.toasts = append(m.toasts[:i], m.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// latestToast is what the single-line mini view shows in its footer.
+func (m *Model) latestToast() (string, bool) {
+	if len(m.toasts) == 0 {
+		return "", false
+	}
+	return m.toasts[len(m.toasts)-1].text, true
+}
+
+// renderToasts draws the stack newest first, wrapped to the sidebar width.
+func (m *Model) renderToasts(width int) []string {
+	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("117")).Width(width)
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Width(width)
+
+	var lines []string
+	for i := len(m.toasts) - 1; i >= 0; i-- {
+		t := m.toasts[i]
+		if t.err {
+			lines = append(lines, errStyle.Render("✗ "+t.text))
+		} else {
+			lines = append(lines, infoStyle.Render("• "+t.text))
+		}
+	}
+	return lines
+}
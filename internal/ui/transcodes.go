@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"ember/internal/player"
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type transcodesMsg struct {
+	transcodes []service.TranscodeInfo
+	err        error
+}
+
+type directPlayMsg struct {
+	item service.MediaItem
+	err  error
+}
+
+func (m *Model) loadTranscodes() tea.Cmd {
+	return func() tea.Msg {
+		transcodes, err := m.svc.GetTranscodes()
+		return transcodesMsg{transcodes: transcodes, err: err}
+	}
+}
+
+func (m *Model) openTranscodes() (tea.Model, tea.Cmd) {
+	m.status = "Loading transcodes..."
+	return m, m.loadTranscodes()
+}
+
+func (m *Model) applyTranscodes(msg transcodesMsg) {
+	if msg.err != nil {
+		m.status = "Cannot list transcodes: " + msg.err.Error()
+		return
+	}
+	m.transcodes = msg.transcodes
+	if m.state != StateTranscodes {
+		m.status = ""
+	}
+	m.state = StateTranscodes
+}
+
+func (m *Model) switchToDirectPlay() tea.Cmd {
+	if m.nowPlaying == "" || m.playing == nil {
+		m.status = "Nothing is playing here"
+		return nil
+	}
+	if m.playMethod != "Transcode" {
+		m.status = "Playback is not transcoding"
+		return nil
+	}
+	item := *m.playing
+	return func() tea.Msg {
+		return directPlayMsg{item: item, err: m.svc.PreferDirectPlay(item.ID)}
+	}
+}
+
+func (m *Model) applyDirectPlay(msg directPlayMsg) {
+	if msg.err != nil {
+		m.status = "Cannot switch to direct play: " + msg.err.Error()
+		return
+	}
+	ctrl := player.Current()
+	if ctrl == nil {
+		return
+	}
+	m.restartItem = &msg.item
+	m.status = "Switching to direct play..."
+	if err := ctrl.Stop(); err != nil {
+		m.restartItem = nil
+		m.status = "Player control failed: " + err.Error()
+	}
+}
+
+func (m *Model) handleTranscodesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "T":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "r":
+		return m, m.loadTranscodes()
+
+	case "D":
+		return m, m.switchToDirectPlay()
+	}
+	return m, nil
+}
+
+func (m *Model) renderTranscodes() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render(fmt.Sprintf("Transcoding Sessions (%d)", len(m.transcodes)))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	mineStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+	var lines []string
+	if m.nowPlaying != "" && m.playMethod != "" {
+		here := "Playing here: " + truncateText(m.nowPlaying, 50) + " via " + m.playMethod
+		style := dimStyle
+		if m.playMethod == "Transcode" {
+			style = warnStyle
+		}
+		lines = append(lines, style.Render(here), "")
+	}
+
+	for _, t := range m.transcodes {
+		who := t.Device + " (" + t.Client + ")"
+		if t.User != "" {
+			who = t.User + " on " + who
+		}
+		style := dimStyle
+		if t.Mine {
+			style = mineStyle
+			who += " - this device"
+		}
+		lines = append(lines, style.Render(truncateText(who, 70)))
+		lines = append(lines, dimStyle.Render("    "+truncateText(t.NowPlaying, 66)))
+
+		video := codecLabel(t.VideoCodec, t.VideoCopy)
+		audio := codecLabel(t.AudioCodec, t.AudioCopy)
+		details := []string{"video " + video, "audio " + audio}
+		if t.Container != "" {
+			details = append(details, t.Container)
+		}
+		if t.Bitrate > 0 {
+			details = append(details, fmt.Sprintf("%.1f Mbps", float64(t.Bitrate)/1_000_000))
+		}
+		if t.Framerate > 0 {
+			details = append(details, fmt.Sprintf("%.0f fps", t.Framerate))
+		}
+		if t.Progress > 0 {
+			details = append(details, fmt.Sprintf("%.0f%% done", t.Progress))
+		}
+		if t.Throttled {
+			details = append(details, "throttled")
+		}
+		lines = append(lines, dimStyle.Render("    "+strings.Join(details, ", ")))
+		if len(t.Reasons) > 0 {
+			lines = append(lines, dimStyle.Render("    why: "+truncateText(strings.Join(t.Reasons, ", "), 60)))
+		}
+	}
+	if len(m.transcodes) == 0 {
+		lines = append(lines, dimStyle.Render("Nothing is transcoding on the server"))
+	}
+
+	hint := "[r] refresh  [esc] back"
+	if m.playMethod == "Transcode" && m.nowPlaying != "" {
+		hint = "[D] switch my playback to direct play  " + hint
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, dimStyle.MarginTop(1).Render(hint))
+}
+
+func codecLabel(codec string, copied bool) string {
+	if codec == "" {
+		codec = "?"
+	}
+	if copied {
+		return "direct (" + codec + ")"
+	}
+	return "→ " + codec
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type pendingPlay struct {
+	item     service.MediaItem
+	startSec int64
+	status   string
+}
+
+func (m *Model) openVersions(item service.MediaItem, startSec int64, status string) (tea.Model, tea.Cmd) {
+	if len(item.MediaSources) < 2 {
+		m.status = item.Name + " has only one version"
+		return m, nil
+	}
+	m.versionPlay = &pendingPlay{item: item, startSec: startSec, status: status}
+	m.versionCursor = 0
+	if current, ok := m.svc.PreferredSource(item); ok {
+		for i, ms := range item.MediaSources {
+			if ms.ID == current.ID {
+				m.versionCursor = i
+			}
+		}
+	}
+	m.state = StateVersions
+	return m, nil
+}
+
+func (m *Model) handleVersionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pending := m.versionPlay
+	if pending == nil {
+		m.state = StateBrowsing
+		return m, nil
+	}
+	sources := pending.item.MediaSources
+
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateBrowsing
+		m.versionPlay = nil
+		return m, nil
+
+	case "up", "k":
+		if m.versionCursor > 0 {
+			m.versionCursor--
+		}
+
+	case "down", "j":
+		if m.versionCursor < len(sources)-1 {
+			m.versionCursor++
+		}
+
+	case "left", "h":
+		if m.versionCompare && m.versionCursor > 0 {
+			m.versionCursor--
+		}
+
+	case "right", "l":
+		if m.versionCompare && m.versionCursor < len(sources)-1 {
+			m.versionCursor++
+		}
+
+	case "c":
+		m.versionCompare = !m.versionCompare
+
+	case "D":
+		if m.versionCursor >= len(sources) {
+			return m, nil
+		}
+		m.svc.SetPreferredSource(pending.item.ID, sources[m.versionCursor].ID)
+		m.state = StateBrowsing
+		m.versionPlay = nil
+		return m.startDownload(pending.item)
+
+	case "enter", "p":
+		if m.versionCursor >= len(sources) {
+			return m, nil
+		}
+		m.svc.SetPreferredSource(pending.item.ID, sources[m.versionCursor].ID)
+		m.state = StateBrowsing
+		m.versionPlay = nil
+		return m.playItemAt(pending.item, pending.startSec, pending.status)
+	}
+
+	return m, nil
+}
+
+func compareRows(ms service.MediaSource) []string {
+	resolution := "-"
+	switch {
+	case ms.Width > 0 && ms.Height > 0:
+		resolution = fmt.Sprintf("%dx%d", ms.Width, ms.Height)
+	case ms.Height > 0:
+		resolution = fmt.Sprintf("%dp", ms.Height)
+	}
+	videoRange := ms.VideoRange
+	if videoRange == "" {
+		videoRange = "SDR"
+	}
+	bitrate, size := "-", "-"
+	if ms.Bitrate > 0 {
+		bitrate = fmt.Sprintf("%.1f Mbps", float64(ms.Bitrate)/1_000_000)
+	}
+	if ms.Size > 0 {
+		size = fmt.Sprintf("%.1f GB", float64(ms.Size)/(1<<30))
+	}
+	name := strings.TrimSpace(ms.Name)
+	if name == "" {
+		name = ms.ID
+	}
+
+	rows := []string{
+		name,
+		resolution,
+		valueOr(strings.ToUpper(ms.VideoCodec), "-"),
+		videoRange,
+		bitrate,
+		size,
+		valueOr(ms.Container, "-"),
+		fmt.Sprintf("%d", len(ms.Subtitles)),
+	}
+	if len(ms.Audio) == 0 {
+		return append(rows, "-")
+	}
+	for _, track := range ms.Audio {
+		label := valueOr(track.Language, "und")
+		if track.Codec != "" {
+			label += " " + track.Codec
+		}
+		if track.Channels > 0 {
+			label += fmt.Sprintf(" %dch", track.Channels)
+		}
+		rows = append(rows, label)
+	}
+	return rows
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func (m *Model) renderVersionCompare(item service.MediaItem) []string {
+	labels := []string{"Version", "Resolution", "Video", "Range", "Bitrate", "Size", "Container", "Subtitles", "Audio"}
+	sources := item.MediaSources
+	colWidth := min(28, max((m.width-16)/len(sources)-2, 10))
+
+	columns := make([][]string, len(sources))
+	rowCount := len(labels)
+	for i, ms := range sources {
+		columns[i] = compareRows(ms)
+		rowCount = max(rowCount, len(columns[i]))
+	}
+
+	labelStyle := lipgloss.NewStyle().Width(12).Foreground(lipgloss.Color("117"))
+	dimStyle := lipgloss.NewStyle().Width(colWidth).MarginRight(2).Foreground(lipgloss.Color("244"))
+	selectedStyle := dimStyle.Bold(true).Foreground(lipgloss.Color("212"))
+
+	lines := make([]string, 0, rowCount)
+	for row := 0; row < rowCount; row++ {
+		label := ""
+		if row < len(labels) {
+			label = labels[row]
+		}
+		cells := []string{labelStyle.Render(label)}
+		for i, column := range columns {
+			value := ""
+			if row < len(column) {
+				value = column[row]
+			}
+			style := dimStyle
+			if i == m.versionCursor {
+				style = selectedStyle
+			}
+			cells = append(cells, style.Render(truncateText(value, colWidth)))
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	return lines
+}
+
+func (m *Model) renderVersions() string {
+	if m.versionPlay == nil {
+		return ""
+	}
+	item := m.versionPlay.item
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Choose a version: " + truncateText(item.Name, 50))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	if m.versionCompare {
+		title = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Compare versions: " + truncateText(item.Name, 50))
+		hint := dimStyle.MarginTop(1).Render("[←→] select  [enter] play and remember  [D] download this version  [c] list  [esc] cancel")
+		content := lipgloss.JoinVertical(lipgloss.Left, m.renderVersionCompare(item)...)
+		return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+	}
+
+	var lines []string
+	for i, ms := range item.MediaSources {
+		style := dimStyle
+		prefix := "  "
+		if i == m.versionCursor {
+			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+			prefix = "> "
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s%d. %s", prefix, i+1, truncateText(service.SourceLabel(ms), 70))))
+	}
+
+	hint := dimStyle.MarginTop(1).Render("[↑↓] select  [enter] play and remember  [D] download  [c] compare  [esc] cancel")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.JoinVertical(lipgloss.Center, title, content, hint)
+}
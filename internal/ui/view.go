@@ -3,7 +3,9 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"ember/internal/logging"
 	"ember/internal/player"
 	"ember/internal/service"
 
@@ -11,29 +13,52 @@ import (
 )
 
 func (m *Model) View() string {
+	return asciiFallback(m.overlayInputLine(m.renderView()))
+}
+
+func (m *Model) renderView() string {
 	if m.width == 0 {
 		return "Loading..."
 	}
+	if m.mini {
+		switch {
+		case m.helpVisible:
+		case m.state == StateBrowsing, m.state == StateLoading, m.state == StateSearching:
+			return m.renderMini()
+		}
+		return m.renderCarousel(m.width, m.height)
+	}
 
-	statusWidth := 32
-	if m.width < 100 {
-		statusWidth = 28
+	if m.helpVisible {
+		return m.renderHelp(m.width, m.height)
 	}
-	contentWidth := m.width - statusWidth
 
-	content := m.renderCarousel(contentWidth, m.height)
-	status := m.renderStatus(statusWidth, m.height)
+	contentWidth, contentHeight := m.contentSize()
+
+	content := m.renderCarousel(contentWidth, contentHeight)
+	status := m.renderStatus(m.statusWidth(), m.height)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, status, content)
 }
 
+func (m *Model) statusWidth() int {
+	if m.width < 100 {
+		return 28
+	}
+	return 32
+}
+
+func (m *Model) contentSize() (int, int) {
+	return m.width - m.statusWidth(), m.height
+}
+
 func (m *Model) renderCarousel(width, height int) string {
 	style := lipgloss.NewStyle().
 		Width(width).
 		Height(height)
 
 	if m.helpVisible {
-		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderHelp(width - 6))
+		return m.renderHelp(width, height)
 	}
 
 	if m.state == StateServerManage {
@@ -44,6 +69,66 @@ func (m *Model) renderCarousel(width, height int) string {
 		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderServerEdit())
 	}
 
+	if m.state == StateSettings {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderSettings())
+	}
+
+	if m.state == StateFilter {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderFilter())
+	}
+
+	if m.state == StateLogPicker {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderLogPicker())
+	}
+
+	if m.state == StateSeasonPicker {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderSeasonPicker())
+	}
+
+	if m.state == StateKioskPicker {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderKioskPicker())
+	}
+
+	if m.state == StateKioskPIN {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderKioskPIN())
+	}
+
+	if m.state == StateScriptPicker {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderScriptPicker())
+	}
+
+	if m.state == StateQueue {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderQueue())
+	}
+
+	if m.state == StateTranscodes {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderTranscodes())
+	}
+
+	if m.state == StateVersions {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderVersions())
+	}
+
+	if m.state == StateAudit {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderAudit())
+	}
+
+	if m.state == StateBulkPlayed {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderBulkPlayed())
+	}
+
+	if m.state == StateSessions {
+		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderSessions())
+	}
+
+	if m.state == StateGuide {
+		return style.Padding(1, 2).Render(m.renderGuide(width-4, height-2))
+	}
+
+	if m.state == StateDetail {
+		return style.Padding(1, 2).Render(m.renderDetail(width-4, height-2))
+	}
+
 	if m.state == StateSearching {
 		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.renderSearch())
 	}
@@ -52,6 +137,10 @@ func (m *Model) renderCarousel(width, height int) string {
 		return style.Align(lipgloss.Center, lipgloss.Center).Render(m.spinner.View() + " Loading...")
 	}
 
+	if m.musicView && m.audioPlan != nil {
+		return style.Padding(1, 2).Render(m.renderMusic(width-4, height-2))
+	}
+
 	if len(m.items) == 0 {
 		parts := []string{lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(m.emptyStateText())}
 		if header := m.renderContentHeader(width); header != "" {
@@ -61,6 +150,10 @@ func (m *Model) renderCarousel(width, height int) string {
 		return style.Align(lipgloss.Center, lipgloss.Center).Render(empty)
 	}
 
+	if m.gridMode {
+		return m.renderGrid(width, height)
+	}
+
 	coverWidth, coverHeight := m.coverFrame(width, height)
 
 	var cover string
@@ -80,7 +173,7 @@ func (m *Model) renderCarousel(width, height int) string {
 		Foreground(lipgloss.Color("244")).
 		Align(lipgloss.Center).
 		Width(width).
-		Render(fmt.Sprintf("< %d / %d >  Page %d  Total %d", m.cursor+1, len(m.items), m.page+1, m.totalItems))
+		Render(m.navLine())
 
 	coverBlock := lipgloss.NewStyle().
 		Width(width).
@@ -88,7 +181,12 @@ func (m *Model) renderCarousel(width, height int) string {
 		Align(lipgloss.Center, lipgloss.Center).
 		Render(cover)
 
-	parts := []string{coverBlock, "", info, nav}
+	var watched string
+	if m.cursor < len(m.items) {
+		watched = lipgloss.PlaceHorizontal(width, lipgloss.Center, watchedBar(m.items[m.cursor], coverWidth))
+	}
+
+	parts := []string{coverBlock, watched, info, nav}
 	if header := m.renderContentHeader(width); header != "" {
 		parts = append([]string{header}, parts...)
 	}
@@ -96,8 +194,16 @@ func (m *Model) renderCarousel(width, height int) string {
 	return style.Align(lipgloss.Center, lipgloss.Top).Render(content)
 }
 
+func (m *Model) navLine() string {
+	line := fmt.Sprintf("< %d / %d >  Page %d  Total %d", m.cursor+1, len(m.items), m.page+1, m.totalItems)
+	if m.quickBase != nil {
+		line += fmt.Sprintf("  Filter %q", m.quickQuery)
+	}
+	return line
+}
+
 func (m *Model) renderCover(item service.MediaItem, width, height int, selected bool) string {
-	if img, ok := m.coverCache[item.ID]; ok && img != "" {
+	if img, ok := m.coverCache.Get(coverKey(item, width, height)); ok && img != "" {
 		imgStyle := lipgloss.NewStyle().
 			Width(width).
 			Height(height).
@@ -125,6 +231,7 @@ func (m *Model) renderPlaceholder(item service.MediaItem, width, height int, sel
 		"CollectionFolder": "LIBRARY",
 		"Folder":           "FOLDER",
 		"BoxSet":           "BOXSET",
+		"Studio":           "STUDIO",
 	}
 
 	label := typeLabels[item.Type]
@@ -169,6 +276,9 @@ func (m *Model) renderItemInfo(item service.MediaItem, width int) string {
 	if item.IndexNumber > 0 {
 		title = fmt.Sprintf("EP %02d - %s", item.IndexNumber, item.Name)
 	}
+	if item.IndexEnd > item.IndexNumber {
+		title = fmt.Sprintf("EP %02d-%02d - %s", item.IndexNumber, item.IndexEnd, item.Name)
+	}
 	if context := itemContext(item); context != "" {
 		title = title + "  /  " + context
 	}
@@ -190,12 +300,19 @@ func (m *Model) renderSearch() string {
 	inputLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
 
 	queryLine := lipgloss.JoinHorizontal(lipgloss.Left, inputLabelStyle.Render("Query:")+" ", m.searchInput.View())
-	lines := []string{title, queryLine, labelStyle.Render("Search by title or keyword.")}
+	mode := "Title"
+	description := "Search by title or keyword."
+	if m.deepSearch {
+		mode = "Deep"
+		description = "Search titles, people, studios, and overviews."
+	}
+	modeLine := inputLabelStyle.Render("Mode:") + "  " + labelStyle.Render(mode)
+	lines := []string{title, queryLine, modeLine, labelStyle.Render(description)}
 	if strings.TrimSpace(m.lastSearchQuery) != "" {
 		lines = append(lines, labelStyle.Render(`Last query: "`+m.lastSearchQuery+`"`))
 	}
 	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).MarginTop(1).Render(
-		"[Enter] search  [Esc] cancel",
+		"[Enter] search  [Tab] deep search  [Esc] cancel",
 	)
 	lines = append(lines, hint)
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
@@ -247,6 +364,9 @@ func (m *Model) renderServerLine(idx int, srv service.ServerInfo, activeIdx int,
 	}
 
 	line := style.Render(prefix + name)
+	if srv.AuthFailed {
+		line += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(" password rejected")
+	}
 
 	if lat, ok := m.serverLatencies[idx]; ok {
 		line += renderLatency(lat.Milliseconds())
@@ -304,35 +424,17 @@ func (m *Model) renderStatus(width, height int) string {
 
 	divider := lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render(strings.Repeat("─", width-4))
 
-	var serverName string
-	if srv := m.svc.GetActiveServer(); srv != nil {
-		serverName = srv.Name
-		if serverName == "" {
-			serverName = srv.URL
-		}
-		if len(serverName) > width-6 {
-			serverName = serverName[:width-9] + "..."
-		}
-	} else {
-		serverName = "(no server)"
-	}
-
-	sections := []struct {
-		key  string
-		name string
-		sec  Section
-	}{
-		{"1", "Continue", SectionResume},
-		{"2", "Favorites", SectionFavorites},
-		{"3", "History", SectionHistory},
-		{"4", "Search", SectionSearch},
-	}
+	sections := sidebarSections
 
 	var navItems []string
+	if m.kiosk != nil {
+		sections = nil
+		navItems = append(navItems, lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).Render(" KIOSK  "+truncateText(m.kiosk.LibraryName, width-13)))
+	}
 	for _, s := range sections {
 		line := fmt.Sprintf(" %s  %s", s.key, s.name)
 		if m.activeSection() == s.sec {
-			line = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")).Render(line)
+			line = lipgloss.NewStyle().Bold(true).Foreground(m.accent()).Render(line)
 		} else {
 			line = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(line)
 		}
@@ -349,15 +451,37 @@ func (m *Model) renderStatus(width, height int) string {
 	logStatus := " OFF"
 	if m.loggingEnabled {
 		logStatus = " ON"
+		on := 0
+		for _, c := range logging.Categories {
+			if logging.CategoryEnabled(c) {
+				on++
+			}
+		}
+		if on < len(logging.Categories) {
+			logStatus = fmt.Sprintf(" ON %d/%d", on, len(logging.Categories))
+		}
 	}
 	logStatus = lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Render(logStatus)
 
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
 	highlightStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("117"))
 
+	watchedStatus := " shown"
+	hideWatched := m.svc.HideWatched()
+	if scope, ok := m.watchedScope(); ok {
+		hideWatched = m.svc.HideWatchedIn(scope)
+	}
+	if hideWatched {
+		label := " hidden"
+		if m.hiddenItems > 0 {
+			label = fmt.Sprintf(" %d hidden", m.hiddenItems)
+		}
+		watchedStatus = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(label)
+	}
+
 	lines := []string{
 		title,
-		dimStyle.Render(serverName),
+		m.sidebarServerLine(width),
 		divider,
 		dimStyle.Render("Navigation:"),
 	}
@@ -369,17 +493,31 @@ func (m *Model) renderStatus(width, height int) string {
 		dimStyle.Render(" Latency:")+latency,
 		dimStyle.Render(" MPV:")+mpvStatus,
 		dimStyle.Render(" Log:")+logStatus,
+		dimStyle.Render(" Watched:")+watchedStatus,
 	)
+	if pending := m.svc.PendingReportCount(); pending > 0 {
+		lines = append(lines, dimStyle.Render(" Sync:")+lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(fmt.Sprintf(" %d pending", pending)))
+	}
+	if m.latestVersion != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(" Update: "+m.latestVersion+" (ember update)"))
+	}
 
+	if strings.TrimSpace(m.status) != "" || len(m.toasts) > 0 {
+		lines = append(lines, "")
+	}
 	if strings.TrimSpace(m.status) != "" {
-		lines = append(lines, "", dimStyle.Render(m.status))
+		lines = append(lines, dimStyle.Render(m.status))
 	}
+	lines = append(lines, m.renderToasts(width)...)
 
 	if path := m.currentBreadcrumb(); path != "" {
 		lines = append(lines, dimStyle.Render(" Path:")+highlightStyle.Render(" "+truncateText(path, width-11)))
 	}
 
-	if m.lastPlayPosition > 0 {
+	if nowPlaying := m.renderNowPlaying(width); len(nowPlaying) > 0 {
+		lines = append(lines, "", divider)
+		lines = append(lines, nowPlaying...)
+	} else if m.lastPlayPosition > 0 {
 		lines = append(lines, "", divider)
 		lines = append(lines, highlightStyle.Render("Last Play:"))
 		lines = append(lines, dimStyle.Render(formatDuration(m.lastPlayPosition)))
@@ -402,6 +540,52 @@ func (m *Model) renderStatus(width, height int) string {
 	return style.Render(strings.Join(lines, "\n"))
 }
 
+var sidebarSections = []struct {
+	key  string
+	name string
+	sec  Section
+}{
+	{"1", "Continue", SectionResume},
+	{"2", "Favorites", SectionFavorites},
+	{"3", "History", SectionHistory},
+	{"4", "Search", SectionSearch},
+	{"5", "New Releases", SectionReleased},
+	{"6", "Studios", SectionStudios},
+	{"7", "Downloads", SectionDownloads},
+	{"8", "Airing Soon", SectionAiring},
+	{"9", "Live TV", SectionLiveTV},
+	{"N", "Recently Added", SectionLatest},
+}
+
+func (m *Model) sidebarServerLine(width int) string {
+	var serverName string
+	if srv := m.svc.GetActiveServer(); srv != nil {
+		serverName = srv.Name
+		if serverName == "" {
+			serverName = srv.URL
+		}
+		if len(serverName) > width-6 {
+			serverName = serverName[:width-9] + "..."
+		}
+	} else {
+		serverName = "(no server)"
+	}
+
+	line := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(serverName)
+	if banner := m.maintenanceBanner(); banner != "" {
+		line += "\n" + banner
+	} else if m.svc.Offline() {
+		line += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("OFFLINE (cached data)")
+	}
+	return line
+}
+
+// sidebarNavTop is the screen row of the first section in the sidebar:
+// padding, title, server line(s), divider and the "Navigation:" label.
+func (m *Model) sidebarNavTop() int {
+	return 2 + lipgloss.Height(m.sidebarServerLine(m.statusWidth())) + 2
+}
+
 func (m *Model) renderContentHeader(width int) string {
 	path := m.currentBreadcrumb()
 	if path == "" {
@@ -415,42 +599,6 @@ func (m *Model) renderContentHeader(width int) string {
 	return breadcrumb
 }
 
-func (m *Model) renderHelp(width int) string {
-	style := lipgloss.NewStyle().
-		Width(width).
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("99")).
-		Padding(1, 2)
-
-	lines := []string{
-		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("117")).Render("Help"),
-		"",
-		"Navigation",
-		"  1/2/3 switch sections",
-		"  4 or / open search",
-		"  left/right move or change page",
-		"  enter open item",
-		"  esc/backspace go back",
-		"",
-		"Playback",
-		"  p play current item",
-		"  R replay from beginning",
-		"  c continuous play for episode",
-		"",
-		"Actions",
-		"  f toggle favorite",
-		"  s jump to season",
-		"  S jump to series",
-		"  r refresh current view",
-		"  m manage servers",
-		"  d toggle debug log",
-		"",
-		"Press ? or Esc to close",
-	}
-
-	return style.Render(strings.Join(lines, "\n"))
-}
-
 func (m *Model) activeSection() Section {
 	if m.state == StateSearching {
 		return SectionSearch
@@ -467,14 +615,42 @@ func (m *Model) currentBreadcrumb() string {
 	}
 	parts := make([]string, 0, 2)
 	switch m.view.mode {
+	case viewHistory:
+		if m.localHistory {
+			parts = append(parts, "Local plays")
+		}
+	case viewLatest:
+		if m.latestByLibrary {
+			parts = append(parts, "By library")
+		}
 	case viewSearch:
 		if strings.TrimSpace(m.lastSearchQuery) != "" {
-			parts = append(parts, "Search", `"`+m.lastSearchQuery+`"`)
+			label := "Search"
+			if m.deepSearch {
+				label = "Deep search"
+			}
+			parts = append(parts, label, `"`+m.lastSearchQuery+`"`)
 		}
 	case viewItems:
 		if m.currentLib != nil && strings.TrimSpace(m.currentLib.Name) != "" {
 			parts = append(parts, m.currentLib.Name)
 		}
+		if summary := filterSummary(m.itemFilter); summary != "" {
+			parts = append(parts, summary)
+		}
+	case viewStudio:
+		parts = append(parts, "Studios")
+		if m.currentLib != nil && strings.TrimSpace(m.currentLib.Name) != "" {
+			parts = append(parts, m.currentLib.Name)
+		}
+	case viewGenres, viewGenre:
+		if m.view.mode == viewGenres && m.currentLib != nil && strings.TrimSpace(m.currentLib.Name) != "" {
+			parts = append(parts, m.currentLib.Name)
+		}
+		parts = append(parts, "Genres")
+		if m.view.mode == viewGenre && m.currentLib != nil && strings.TrimSpace(m.currentLib.Name) != "" {
+			parts = append(parts, m.currentLib.Name)
+		}
 	case viewSeasons:
 		if len(m.items) > 0 && strings.TrimSpace(m.items[0].SeriesName) != "" {
 			parts = append(parts, m.items[0].SeriesName)
@@ -514,9 +690,33 @@ func itemMeta(item service.MediaItem) []string {
 	if item.Year > 0 {
 		parts = append(parts, fmt.Sprintf("%d", item.Year))
 	}
+	if date := formatPremiereDate(item.PremiereDate); date != "" && item.Type == "Movie" {
+		parts = append(parts, "Released "+date)
+	}
+	if date := formatPremiereDate(item.PremiereDate); date != "" && item.Type == "Episode" && date > time.Now().Format("2006-01-02") {
+		parts = append(parts, "Airs "+date)
+	}
+	if item.Type == "Series" && item.Status != "" {
+		parts = append(parts, item.Status)
+	}
+	if item.Type == "Genre" && item.ChildCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d titles", item.ChildCount))
+	}
+	if item.Library != "" {
+		parts = append(parts, "in "+item.Library)
+	}
+	if item.Channel != "" {
+		parts = append(parts, "Ch "+item.Channel)
+	}
+	if item.NowShowing != "" {
+		parts = append(parts, "Now: "+item.NowShowing)
+	}
 	if item.RunTimeTicks > 0 {
 		parts = append(parts, formatDuration(item.RunTimeTicks/10000000))
 	}
+	if item.Rating > 0 {
+		parts = append(parts, fmt.Sprintf("★ %.1f", item.Rating))
+	}
 	if item.UserData != nil {
 		switch {
 		case item.UserData.Played:
@@ -527,6 +727,22 @@ func itemMeta(item service.MediaItem) []string {
 		if item.UserData.IsFavorite {
 			parts = append(parts, "Favorite")
 		}
+		if likes := item.UserData.Likes; likes != nil {
+			if *likes {
+				parts = append(parts, "Liked")
+			} else {
+				parts = append(parts, "Disliked")
+			}
+		}
+	}
+	if item.MatchType != "" && item.MatchType != "title" {
+		parts = append(parts, "via "+item.MatchType)
+	}
+	switch item.Download {
+	case "verified":
+		parts = append(parts, "✓ Verified copy")
+	case "unverified":
+		parts = append(parts, "Unverified copy")
 	}
 	return parts
 }
@@ -553,7 +769,28 @@ func (m *Model) emptyStateText() string {
 	case viewFavorites:
 		return "No favorites yet"
 	case viewHistory:
+		if m.localHistory {
+			return "No local playback recorded yet"
+		}
 		return "No watch history"
+	case viewReleased:
+		return fmt.Sprintf("No releases in the last %d days", m.svc.ReleaseWindowDays())
+	case viewAiring:
+		return "Nothing scheduled to air"
+	case viewChannels:
+		return "No live TV channels"
+	case viewLatest:
+		return "Nothing added recently"
+	case viewStudios:
+		return "No studios"
+	case viewStudio:
+		return "No titles from this studio"
+	case viewGenres:
+		return "No genres"
+	case viewGenre:
+		return "No titles in this genre"
+	case viewDownloads:
+		return "No downloads yet (D on an item)"
 	case viewSearch:
 		if strings.TrimSpace(m.lastSearchQuery) == "" {
 			return "Enter a keyword to search"
@@ -580,6 +817,18 @@ func (m *Model) loadErrorText(err error) string {
 		return "Failed to load favorites: " + err.Error()
 	case viewHistory:
 		return "Failed to load history: " + err.Error()
+	case viewReleased:
+		return "Failed to load new releases: " + err.Error()
+	case viewAiring:
+		return "Failed to load upcoming episodes: " + err.Error()
+	case viewChannels:
+		return "Failed to load channels: " + err.Error()
+	case viewLatest:
+		return "Failed to load recently added: " + err.Error()
+	case viewStudios, viewStudio:
+		return "Failed to load studios: " + err.Error()
+	case viewGenres, viewGenre:
+		return "Failed to load genres: " + err.Error()
 	case viewItems:
 		return "Failed to load library: " + err.Error()
 	case viewSeasons:
@@ -592,26 +841,10 @@ func (m *Model) loadErrorText(err error) string {
 }
 
 func (m *Model) statusActions() []string {
-	actions := []string{
-		" ←→  move",
-		" ↵   open",
-		" esc back",
+	var actions []string
+	for _, b := range m.contextBindings() {
+		actions = append(actions, " "+lipgloss.NewStyle().Width(6).Render(m.bindingKeys(b))+b.short)
 	}
-
-	item, ok := m.currentItem()
-	if ok {
-		if item.Playable {
-			actions = append(actions, " p   play", " R   replay")
-		}
-		if item.Type == "Episode" {
-			actions = append(actions, " c   continuous", " s   season", " S   series")
-		} else if item.Type == "Season" {
-			actions = append(actions, " S   series")
-		}
-		actions = append(actions, " f   toggle fav")
-	}
-
-	actions = append(actions, " r   refresh", " 4,/ search", " ?   help", " q   quit")
 	return actions
 }
 
@@ -641,6 +874,13 @@ func (m *Model) coverFrame(width, height int) (int, int) {
 	return coverWidth, coverHeight
 }
 
+func formatPremiereDate(value string) string {
+	if len(value) < len("2006-01-02") {
+		return ""
+	}
+	return value[:len("2006-01-02")]
+}
+
 func formatDuration(sec int64) string {
 	h := sec / 3600
 	m := (sec % 3600) / 60
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+
+	"ember/internal/service"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// watchedBar is the one-row watch state drawn under a cover: a check mark
+// for played items, a thin progress bar for partially watched ones and
+// blank space otherwise, so rows line up whatever the state.
+func watchedBar(item service.MediaItem, width int) string {
+	blank := strings.Repeat(" ", max(width, 0))
+	if item.UserData == nil || width < 3 {
+		return blank
+	}
+	if item.UserData.Played {
+		return lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Foreground(lipgloss.Color("78")).Render("✓ watched")
+	}
+	pct := item.UserData.PlaybackPositionPct
+	if pct <= 0 {
+		return blank
+	}
+	filled := max(1, min(width, width*pct/100))
+	return lipgloss.NewStyle().Foreground(itemAccent(item)).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render(strings.Repeat("─", width-filled))
+}
+
+// setPlaybackPosition records a new resume point and keeps the percentage
+// the watched bars read in step with it.
+func setPlaybackPosition(item *service.MediaItem, positionSec int64) {
+	if item.UserData == nil {
+		item.UserData = &service.UserData{}
+	}
+	item.UserData.PlaybackPositionTicks = positionSec * 10000000
+	item.UserData.PlaybackPositionPct = 0
+	if item.RunTimeTicks > 0 {
+		item.UserData.PlaybackPositionPct = int(float64(item.UserData.PlaybackPositionTicks) / float64(item.RunTimeTicks) * 100)
+	}
+}
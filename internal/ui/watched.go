@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+
+	"ember/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type bulkPlayedRun struct {
+	name     string
+	played   bool
+	episodes []service.MediaItem
+	done     int
+	failed   int
+}
+
+type bulkEpisodesMsg struct {
+	name     string
+	played   bool
+	episodes []service.MediaItem
+	err      error
+}
+
+type bulkPlayedStepMsg struct {
+	err error
+}
+
+func (m *Model) bulkPlayedTarget() (service.MediaItem, bool) {
+	switch m.view.mode {
+	case viewSeasons:
+		name := "this series"
+		if len(m.items) > 0 && m.items[0].SeriesName != "" {
+			name = m.items[0].SeriesName
+		}
+		return service.MediaItem{ID: m.view.seriesID, Type: "Series", Name: name}, m.view.seriesID != ""
+	case viewEpisodes:
+		name := "this season"
+		if len(m.items) > 0 && m.items[0].SeasonName != "" {
+			name = m.items[0].SeasonName
+			if m.items[0].SeriesName != "" {
+				name = m.items[0].SeriesName + " " + name
+			}
+		}
+		return service.MediaItem{ID: m.view.seasonID, Type: "Season", SeriesID: m.view.seriesID, Name: name}, m.view.seasonID != ""
+	}
+	return service.MediaItem{}, false
+}
+
+func (m *Model) openBulkPlayed(item service.MediaItem) (tea.Model, tea.Cmd) {
+	if m.bulkPlayed != nil {
+		m.status = "Still updating watched state of " + m.bulkPlayed.name
+		return m, nil
+	}
+	m.bulkPlayedItem = item
+	m.state = StateBulkPlayed
+	return m, nil
+}
+
+func (m *Model) handleBulkPlayedKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "n":
+		m.state = StateBrowsing
+		return m, nil
+
+	case "w", "u":
+		played := msg.String() == "w"
+		item := m.bulkPlayedItem
+		m.state = StateBrowsing
+		m.status = "Listing episodes of " + item.Name + "..."
+		return m, func() tea.Msg {
+			episodes, err := m.svc.EpisodesToMark(item, played)
+			return bulkEpisodesMsg{name: item.Name, played: played, episodes: episodes, err: err}
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) applyBulkEpisodes(msg bulkEpisodesMsg) tea.Cmd {
+	if msg.err != nil {
+		m.status = ""
+		return m.notifyError("Watched error: " + msg.err.Error())
+	}
+	if len(msg.episodes) == 0 {
+		m.status = fmt.Sprintf("Every episode of %s is already %s", msg.name, playedLabel(msg.played))
+		return nil
+	}
+	m.bulkPlayed = &bulkPlayedRun{name: msg.name, played: msg.played, episodes: msg.episodes}
+	return m.nextBulkPlayed()
+}
+
+func (m *Model) nextBulkPlayed() tea.Cmd {
+	run := m.bulkPlayed
+	if run.done == len(run.episodes) {
+		m.bulkPlayed = nil
+		m.status = ""
+		result := fmt.Sprintf("Marked %d episode(s) of %s as %s", run.done-run.failed, run.name, playedLabel(run.played))
+		if run.failed > 0 {
+			return m.notifyError(result + fmt.Sprintf(" (%d failed)", run.failed))
+		}
+		return m.notify(result)
+	}
+
+	m.status = fmt.Sprintf("Marking %s as %s %d/%d...", run.name, playedLabel(run.played), run.done+1, len(run.episodes))
+	episode := run.episodes[run.done]
+	return func() tea.Msg {
+		_, err := m.svc.SetPlayed(episode.ID, run.played)
+		return bulkPlayedStepMsg{err: err}
+	}
+}
+
+func (m *Model) applyBulkPlayedStep(msg bulkPlayedStepMsg) tea.Cmd {
+	if m.bulkPlayed == nil {
+		return nil
+	}
+	m.bulkPlayed.done++
+	if msg.err != nil {
+		m.bulkPlayed.failed++
+	}
+	return m.nextBulkPlayed()
+}
+
+func playedLabel(played bool) string {
+	if played {
+		return "watched"
+	}
+	return "unwatched"
+}
+
+func (m *Model) renderBulkPlayed() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).MarginBottom(1).Render("Watched State")
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	prompt := "Mark every episode of " + truncateText(m.bulkPlayedItem.Name, 50) + " as:"
+	hint := dimStyle.MarginTop(1).Render("[w] watched  [u] unwatched  [esc] cancel")
+	return lipgloss.JoinVertical(lipgloss.Center, title, prompt, hint)
+}
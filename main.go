@@ -1,57 +1,147 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"ember/internal/api"
+	"ember/internal/config"
 	"ember/internal/player"
 	"ember/internal/service"
 	"ember/internal/storage"
 	"ember/internal/ui"
 )
 
+var version = "dev"
+
+var commands = map[string]func(args []string) error{
+	"doctor":   runDoctor,
+	"download": runDownload,
+	"play":     runPlay,
+	"report":   runReport,
+	"resume":   runResume,
+	"stats":    runStats,
+	"search":   runSearch,
+	"update":   runUpdate,
+}
+
 func main() {
-	if !player.Available() {
-		fmt.Println("Warning: mpv not found")
-		fmt.Println("Install with: brew install mpv")
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 	}
 
-	store, err := storage.New()
-	if err != nil {
-		fmt.Printf("Error initializing storage: %v\n", err)
+	mini := flag.Bool("mini", false, "compact list mode for tmux popups and small terminals")
+	renderer := flag.String("renderer", "", "image renderer: "+strings.Join(ui.ImageRendererNames(), ", "))
+	flag.Parse()
+
+	cfg, _ := config.Load()
+	svc, problems := startup()
+	if len(problems) > 0 {
+		proceed, err := ui.ShowProblems(problems)
+		if err != nil || !proceed {
+			for _, p := range problems {
+				fmt.Printf("%s: %s\n", p.Check, p.Message)
+			}
+			os.Exit(1)
+		}
+	}
+
+	if *renderer == "" {
+		*renderer = cfg.Images.Renderer
+	}
+	opts := ui.Options{
+		Mini:     *mini,
+		Version:  version,
+		Renderer: *renderer,
+		Quality:  cfg.Images.Quality,
+		PageSize: cfg.PageSize,
+		Accent:   cfg.Theme.Accent,
+		Keys:     cfg.Keys,
+	}
+	if err := ui.Run(svc, opts); err != nil {
+		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+}
 
-	client := initClient(store)
+func newService() (*service.MediaService, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Player.Path != "" {
+		if err := player.SetPath(cfg.Player.Path); err != nil {
+			return nil, err
+		}
+	}
 
-	svc := service.NewMediaService(client, store)
+	store, err := storage.New()
+	if err != nil {
+		return nil, err
+	}
+	client, err := initClient(context.Background(), store)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	return buildService(cfg, store, client), nil
+}
 
-	if err := ui.Run(svc); err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+func buildService(cfg config.Config, store *storage.Store, client *api.Client) *service.MediaService {
+	svc := service.NewMediaService(client, store)
+	if cfg.Player.Profile != "" || len(cfg.Player.Args) > 0 {
+		svc.OverrideMPVArgs(cfg.Player.Profile, cfg.Player.Args)
 	}
+	return svc
 }
 
-func initClient(store *storage.Store) *api.Client {
+func newClient(store *storage.Store) *api.Client {
 	srv := store.GetActiveServer()
 	if srv == nil {
 		return api.New("")
 	}
-
 	client := api.New(srv.URL)
 	client.UserID = srv.UserID
 	client.Token = srv.Token
+	return client
+}
 
-	if client.VerifyToken() {
-		return client
+// initClient verifies the saved token and logs in again if needed. Once
+// ctx is done the result is no longer wanted, so nothing is written back
+// to the store.
+func initClient(ctx context.Context, store *storage.Store) (*api.Client, error) {
+	client := newClient(store)
+	srv := store.GetActiveServer()
+	if srv == nil || client.VerifyToken() {
+		return client, nil
+	}
+	if srv.AuthFailed {
+		return client, fmt.Errorf("login skipped: %w", service.ErrLoginSuspended)
+	}
+	if err := ctx.Err(); err != nil {
+		return client, err
 	}
 
-	if err := client.Login(srv.Username, srv.Password); err != nil {
-		fmt.Printf("Login failed: %v\n", err)
-		return client
+	err := service.Login(client, srv.Username, srv.Password)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return client, ctxErr
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrWrongPassword) {
+			store.SetServerAuthFailed(store.GetActiveServerIndex(), true)
+		}
+		return client, fmt.Errorf("login failed: %w", err)
 	}
 
 	store.SaveServerToken(store.GetActiveServerIndex(), client.UserID, client.Token)
-	return client
+	return client, nil
 }
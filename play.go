@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"ember/internal/player"
+	"ember/internal/service"
+
+	"github.com/google/uuid"
+)
+
+func runPlay(args []string) error {
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	fromStart := fs.Bool("from-start", false, "ignore the saved position and start from the beginning")
+	profile := fs.String("mpv-profile", "", "mpv profile for this playback (overrides mpv_profile)")
+	mpvArgs := fs.String("mpv-args", "", "extra space-separated mpv arguments for this playback")
+	fs.Usage = func() {
+		fmt.Println("Usage: ember play [-from-start] [-mpv-profile name] [-mpv-args args] <item-id | name>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	query := strings.Join(fs.Args(), " ")
+	if query == "" {
+		fs.Usage()
+		return fmt.Errorf("missing item id or name")
+	}
+
+	svc, err := playbackService()
+	if err != nil {
+		return err
+	}
+	svc.OverrideMPVArgs(*profile, strings.Fields(*mpvArgs))
+
+	item, err := svc.ResolveItem(query)
+	if err != nil {
+		return err
+	}
+	return playItem(svc, *item, *fromStart)
+}
+
+func playbackService() (*service.MediaService, error) {
+	if !player.Available() {
+		return nil, fmt.Errorf("mpv player not available")
+	}
+
+	svc, err := newService()
+	if err != nil {
+		return nil, err
+	}
+	if svc.GetActiveServer() == nil {
+		return nil, fmt.Errorf("no server configured; add one in the TUI first")
+	}
+	if synced, _ := svc.SyncPendingReports(); synced > 0 {
+		fmt.Printf("Synced %d pending playback report(s)\n", synced)
+	}
+	return svc, nil
+}
+
+func playItem(svc *service.MediaService, item service.MediaItem, fromStart bool) error {
+	info, err := svc.GetStreamInfo(item.ID)
+	if err != nil {
+		return fmt.Errorf("cannot play %s: %w", item.Name, err)
+	}
+
+	startSec := info.PositionSec
+	if fromStart {
+		startSec = 0
+	}
+	sessionID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	title := displayTitle(item)
+
+	fmt.Printf("Playing %s [%s]", title, item.ID)
+	if startSec > 0 {
+		fmt.Printf(" from %s", formatClock(startSec))
+	}
+	if info.PlayMethod == "Transcode" {
+		fmt.Print(" (transcoding)")
+	}
+	fmt.Println()
+
+	stop := stopOnSignal()
+	result := player.PlayWithProgress(info.StreamURL, title, info.SubtitleURLs, startSec, func() {
+		if err := svc.ReportPlaybackStart(item.ID, info.MediaSourceID, sessionID, startSec); err != nil {
+			fmt.Printf("Warning: failed to report start: %v\n", err)
+		}
+	}, func(positionSec int64) {
+		_ = svc.ReportPlaybackProgress(item.ID, info.MediaSourceID, sessionID, positionSec)
+		fmt.Printf("\rPosition %s", formatClock(positionSec))
+	}, svc.PlayerMetadata(item))
+	stop()
+	fmt.Print("\r")
+
+	reportErr := svc.ReportPlaybackStopped(item.ID, info.MediaSourceID, sessionID, result.PositionSec, info.Duration)
+	if err := svc.DiagnosePlayback(info.StreamURL, result.Err); err != nil {
+		if remedy := service.ErrorRemedy(err); remedy != "" {
+			return fmt.Errorf("%w (%s)", err, remedy)
+		}
+		return err
+	}
+
+	fmt.Printf("Stopped at %s\n", formatClock(result.PositionSec))
+	if reportErr != nil {
+		return fmt.Errorf("failed to report progress: %w", reportErr)
+	}
+	return nil
+}
+
+func stopOnSignal() func() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		if _, ok := <-sigs; ok {
+			player.Shutdown(3 * time.Second)
+		}
+	}()
+	return func() {
+		signal.Stop(sigs)
+		close(sigs)
+	}
+}
+
+func displayTitle(item service.MediaItem) string {
+	if item.Type == "Episode" && item.SeriesName != "" {
+		return fmt.Sprintf("%s - %s E%02d %s", item.SeriesName, item.SeasonName, item.IndexNumber, item.Name)
+	}
+	if item.Year > 0 {
+		return fmt.Sprintf("%s (%d)", item.Name, item.Year)
+	}
+	return item.Name
+}
+
+func formatClock(sec int64) string {
+	d := time.Duration(sec) * time.Second
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
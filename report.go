@@ -0,0 +1,156 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"ember/internal/logging"
+	"ember/internal/player"
+	"ember/internal/storage"
+)
+
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(api_key=)[^&\s"\\]+`),
+	regexp.MustCompile(`(?i)((?:AccessToken|Password|Pw|Token|X-Emby-Token)\\?"\s*:\s*\\?")[^"\\]*`),
+	regexp.MustCompile(`(?i)(Token=\\?")[^"\\]*`),
+}
+
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	output := fs.String("o", "", "output archive (default ember-report-<timestamp>.zip)")
+	lines := fs.Int("lines", 2000, "number of recent log lines to include")
+	fs.Parse(args)
+
+	if *output == "" {
+		*output = "ember-report-" + time.Now().Format("20060102-150405") + ".zip"
+	}
+
+	store, err := storage.New()
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	mainLog := redact(tailLines(filepath.Join(logging.Dir(), "ember.log"), *lines))
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"system.txt", systemInfo()},
+		{"config.json", sanitizedConfig(store)},
+		{"ember.log", mainLog},
+		{"image-errors.log", redact(tailLines(filepath.Join(logging.Dir(), "image-errors.log"), *lines))},
+		{"api-responses.log", apiResponses(mainLog, 50)},
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", file.name, err)
+		}
+		if _, err := w.Write([]byte(file.content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish report: %w", err)
+	}
+
+	fmt.Printf("Report written to %s\n", *output)
+	fmt.Println("Tokens and passwords are redacted; review the archive before attaching it to an issue.")
+	return nil
+}
+
+func systemInfo() string {
+	mpv := player.Version()
+	if mpv == "" {
+		mpv = "not found"
+	}
+
+	lines := []string{
+		"ember:    " + version,
+		"go:       " + runtime.Version(),
+		"os/arch:  " + runtime.GOOS + "/" + runtime.GOARCH,
+		"mpv:      " + mpv,
+		"time:     " + time.Now().Format(time.RFC3339),
+	}
+	for _, key := range []string{"TERM", "TERM_PROGRAM", "TERM_PROGRAM_VERSION", "COLORTERM", "TMUX", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			lines = append(lines, fmt.Sprintf("%-9s %s", key+":", value))
+		}
+	}
+	lines = append(lines, "", terminalReport())
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func sanitizedConfig(store *storage.Store) string {
+	servers := store.GetServers()
+	for i := range servers {
+		if servers[i].Password != "" {
+			servers[i].Password = "REDACTED"
+		}
+		if servers[i].Token != "" {
+			servers[i].Token = "REDACTED"
+		}
+	}
+
+	data, err := json.MarshalIndent(storage.ServerConfig{
+		Servers:      servers,
+		ActiveServer: store.GetActiveServerIndex(),
+		Settings:     store.GetSettings(),
+	}, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data) + "\n"
+}
+
+func apiResponses(log string, limit int) string {
+	var matched []string
+	for _, line := range strings.Split(log, "\n") {
+		if strings.Contains(line, "HTTP request") {
+			matched = append(matched, line)
+		}
+	}
+	if len(matched) > limit {
+		matched = matched[len(matched)-limit:]
+	}
+	if len(matched) == 0 {
+		return ""
+	}
+	return strings.Join(matched, "\n") + "\n"
+}
+
+func tailLines(path string, n int) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Sprintf("unavailable: %v\n", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func redact(text string) string {
+	for _, re := range secretPatterns {
+		text = re.ReplaceAllString(text, "${1}REDACTED")
+	}
+	return text
+}
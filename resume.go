@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+func runResume(args []string) error {
+	fs := flag.NewFlagSet("resume", flag.ExitOnError)
+	play := fs.Bool("play", false, "start playback immediately")
+	fs.Usage = func() {
+		fmt.Println("Usage: ember resume [-play]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *play {
+		svc, err := playbackService()
+		if err != nil {
+			return err
+		}
+		item, err := svc.LastResumeItem()
+		if err != nil {
+			return err
+		}
+		return playItem(svc, *item, false)
+	}
+
+	svc, err := newService()
+	if err != nil {
+		return err
+	}
+	if svc.GetActiveServer() == nil {
+		return fmt.Errorf("no server configured; add one in the TUI first")
+	}
+	item, err := svc.LastResumeItem()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s [%s]", displayTitle(*item), item.ID)
+	if item.UserData != nil && item.UserData.PlaybackPositionTicks > 0 {
+		fmt.Printf(" at %s", formatClock(item.UserData.PlaybackPositionTicks/10_000_000))
+	}
+	fmt.Println()
+	return nil
+}
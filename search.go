@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print results as JSON")
+	limit := fs.Int("limit", 50, "maximum number of results")
+	deep := fs.Bool("deep", false, "also match people, studios and overviews")
+	fs.Usage = func() {
+		fmt.Println("Usage: ember search [-json] [-deep] [-limit n] <query>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	query := strings.Join(fs.Args(), " ")
+	if query == "" {
+		fs.Usage()
+		return fmt.Errorf("missing search query")
+	}
+
+	svc, err := newService()
+	if err != nil {
+		return err
+	}
+	if svc.GetActiveServer() == nil {
+		return fmt.Errorf("no server configured; add one in the TUI first")
+	}
+
+	search := svc.Search
+	if *deep {
+		search = svc.DeepSearch
+	}
+	list, err := search(query, *limit)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list.Items)
+	}
+
+	for _, item := range list.Items {
+		year := ""
+		if item.Year > 0 {
+			year = fmt.Sprintf("%d", item.Year)
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\n", item.ID, item.Type, year, displayTitle(item))
+	}
+	return nil
+}
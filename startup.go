@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"ember/internal/api"
+	"ember/internal/config"
+	"ember/internal/player"
+	"ember/internal/service"
+	"ember/internal/storage"
+	"ember/internal/ui"
+)
+
+const defaultStartupTimeout = 10 * time.Second
+
+type startupCheck struct {
+	name string
+	run  func(ctx context.Context) *ui.Problem
+}
+
+// startup runs the startup checks in parallel and builds the service from
+// whatever they managed to set up. Problems come back in check order so
+// the problem screen is stable between runs.
+func startup() (*service.MediaService, []ui.Problem) {
+	cfg, cfgErr := config.Load()
+	store, _ := storage.New()
+	timeout := defaultStartupTimeout
+	if cfg.Startup.TimeoutSec > 0 {
+		timeout = time.Duration(cfg.Startup.TimeoutSec) * time.Second
+	}
+
+	// The mpv path applies even when the player check is skipped; the
+	// check only reports whether it worked.
+	var pathErr error
+	if cfg.Player.Path != "" {
+		pathErr = player.SetPath(cfg.Player.Path)
+	}
+
+	clients := make(chan *api.Client, 1)
+	checks := []startupCheck{
+		{"config", func(context.Context) *ui.Problem { return checkConfig(cfgErr) }},
+		{"storage", func(context.Context) *ui.Problem { return checkStorage(store) }},
+		{"player", func(context.Context) *ui.Problem { return checkPlayer(pathErr) }},
+		{"server", func(ctx context.Context) *ui.Problem {
+			client, problem := checkServer(ctx, store)
+			clients <- client
+			return problem
+		}},
+	}
+
+	results := make([]*ui.Problem, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		if slices.Contains(cfg.Startup.Skip, check.name) {
+			if check.name == "server" {
+				clients <- newClient(store)
+			}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runWithTimeout(check, timeout)
+		}()
+	}
+	wg.Wait()
+
+	var client *api.Client
+	select {
+	case client = <-clients:
+	default:
+		client = newClient(store)
+	}
+
+	var problems []ui.Problem
+	for _, p := range results {
+		if p != nil {
+			problems = append(problems, *p)
+		}
+	}
+	return buildService(cfg, store, client), problems
+}
+
+// runWithTimeout gives up on a check after timeout and cancels its
+// context, so a check still running in the background knows to leave
+// shared state alone.
+func runWithTimeout(check startupCheck, timeout time.Duration) *ui.Problem {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan *ui.Problem, 1)
+	go func() { done <- check.run(ctx) }()
+	select {
+	case p := <-done:
+		return p
+	case <-ctx.Done():
+		return &ui.Problem{
+			Check:   check.name,
+			Message: fmt.Sprintf("did not finish within %s", timeout),
+			Hint:    fmt.Sprintf("Raise timeout_sec under [startup] in %s, or add %q to skip", config.Path(), check.name),
+		}
+	}
+}
+
+func checkConfig(err error) *ui.Problem {
+	if err == nil {
+		return nil
+	}
+	return &ui.Problem{
+		Check:   "config",
+		Message: err.Error(),
+		Hint:    "Fix or remove " + config.Path() + "; ember doctor shows what was read",
+		Fatal:   true,
+	}
+}
+
+func checkStorage(store *storage.Store) *ui.Problem {
+	if err := store.CheckWritable(); err != nil {
+		return &ui.Problem{
+			Check:   "storage",
+			Message: "cannot write Ember's data: " + err.Error(),
+			Hint:    "Check the permissions and free space of " + storage.Dir() + " and of data_dir, if set",
+			Fatal:   true,
+		}
+	}
+	return nil
+}
+
+func checkPlayer(pathErr error) *ui.Problem {
+	if pathErr != nil {
+		return &ui.Problem{
+			Check:   "player",
+			Message: pathErr.Error(),
+			Hint:    "Fix path under [player] in " + config.Path() + " or EMBER_MPV_PATH",
+		}
+	}
+	if !player.Available() {
+		return &ui.Problem{
+			Check:   "player",
+			Message: "mpv not found, playback is disabled",
+			Hint:    "Install mpv (brew install mpv, apt install mpv) and make sure it is in PATH",
+		}
+	}
+	return nil
+}
+
+func checkServer(ctx context.Context, store *storage.Store) (*api.Client, *ui.Problem) {
+	client, err := initClient(ctx, store)
+	if err == nil {
+		return client, nil
+	}
+
+	srv := store.GetActiveServer()
+	problem := &ui.Problem{Check: "server", Message: fmt.Sprintf("%s (%s): %v", srv.Name, srv.URL, err)}
+	switch {
+	case errors.Is(err, service.ErrServerUnreachable):
+		problem.Hint = "Check that the server is running and reachable from here; downloads (7) still play offline"
+	case errors.Is(err, service.ErrNotEmbyServer):
+		problem.Hint = "Check the server URL: press m, select the server and press e"
+	case errors.Is(err, service.ErrWrongPassword), errors.Is(err, service.ErrLoginSuspended):
+		problem.Hint = "Re-enter the password: press m, select the server and press e"
+	}
+	return client, problem
+}
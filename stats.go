@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const chartWidth = 30
+
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	chart := fs.Bool("chart", false, "render bar charts")
+	asJSON := fs.Bool("json", false, "print stats as JSON")
+	limit := fs.Int("limit", 10, "maximum rows per table")
+	enable := fs.Bool("enable", false, "turn on local analytics")
+	disable := fs.Bool("disable", false, "turn off local analytics")
+	fs.Usage = func() {
+		fmt.Println("Usage: ember stats [-chart] [-json] [-limit n] [-enable|-disable]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	svc, err := newService()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *enable:
+		svc.SetAnalytics(true)
+		fmt.Println("Local analytics enabled; genres are recorded from the next playback on")
+		return nil
+	case *disable:
+		svc.SetAnalytics(false)
+		fmt.Println("Local analytics disabled")
+		return nil
+	}
+
+	if !svc.Analytics() {
+		return fmt.Errorf("local analytics is off; enable it with ember stats -enable")
+	}
+
+	stats := svc.UsageStats(*limit)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+	if stats.Plays == 0 {
+		fmt.Println("No playback recorded yet")
+		return nil
+	}
+
+	fmt.Printf("Watched %s over %d plays, %.0f%% completed\n", formatHours(stats.WatchedSec), stats.Plays, stats.CompletionRate()*100)
+	if *chart {
+		fmt.Printf("Completion  %s\n", bar(stats.CompletionRate(), chartWidth))
+	}
+
+	fmt.Println()
+	fmt.Println("Hours by genre")
+	var top int64
+	if len(stats.Genres) > 0 {
+		top = stats.Genres[0].WatchedSec
+	}
+	for _, g := range stats.Genres {
+		if *chart {
+			fmt.Printf("  %-16s %s %s\n", truncate(g.Name, 16), bar(ratio(g.WatchedSec, top), chartWidth), formatHours(g.WatchedSec))
+			continue
+		}
+		fmt.Printf("  %-16s %8s  %d plays\n", truncate(g.Name, 16), formatHours(g.WatchedSec), g.Plays)
+	}
+
+	if len(stats.Abandoned) > 0 {
+		fmt.Println()
+		fmt.Println("Most abandoned")
+		for _, t := range stats.Abandoned {
+			if *chart {
+				fmt.Printf("  %-30s %s %d/%d\n", truncate(t.Name, 30), bar(ratio(int64(t.Abandoned), int64(t.Started)), chartWidth/2), t.Abandoned, t.Started)
+				continue
+			}
+			fmt.Printf("  %-30s %d of %d unfinished\n", truncate(t.Name, 30), t.Abandoned, t.Started)
+		}
+	}
+	return nil
+}
+
+func ratio(v, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(v) / float64(total)
+}
+
+func bar(fraction float64, width int) string {
+	filled := int(fraction*float64(width) + 0.5)
+	filled = max(0, min(width, filled))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+func formatHours(sec int64) string {
+	return fmt.Sprintf("%.1fh", float64(sec)/3600)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"ember/internal/update"
+)
+
+func runUpdate(args []string) error {
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	checkOnly := fs.Bool("check", false, "only report whether a newer version exists")
+	force := fs.Bool("force", false, "install the latest release even if it is not newer")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	release, err := update.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	newer := update.IsNewer(release.TagName, version)
+	fmt.Printf("Current: %s  Latest: %s\n", version, release.TagName)
+	if !newer && !*force {
+		fmt.Println("Already up to date.")
+		return nil
+	}
+	if *checkOnly {
+		fmt.Printf("Update available: %s\n", release.HTMLURL)
+		return nil
+	}
+
+	fmt.Printf("Downloading %s...\n", update.AssetName())
+	if err := update.Apply(ctx, release); err != nil {
+		return err
+	}
+	fmt.Printf("Updated to %s\n", release.TagName)
+	return nil
+}